@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/amecky/table/heatmap"
+	"github.com/amecky/table/table"
+	term "github.com/amecky/table/term"
+)
+
+var symbols = []string{"AAA", "BBB", "CCC", "DDD"}
+
+type dashboard struct {
+	prices *table.Table
+	viewer *table.Viewer
+	hm     *heatmap.HeatMap
+	layout *term.Stack
+	ticks  int
+}
+
+func newDashboard() *dashboard {
+	d := &dashboard{
+		prices: table.New().Headers("Symbol", "Price"),
+		hm:     heatmap.New("Activity"),
+	}
+	for _, s := range symbols {
+		d.update(s, 100.0)
+		d.hm.CreateLine(s)
+	}
+	d.viewer = table.NewViewer(d.prices)
+	d.layout = term.HStack(d.viewer, term.Static(d.hm))
+	return d
+}
+
+func (d *dashboard) update(symbol string, price float64) {
+	d.prices.UpsertRow(symbol, func(r *table.Row) {
+		r.AddDefaultText(symbol)
+		r.AddFloat(price, 0)
+	})
+}
+
+func (d *dashboard) Init() term.Cmd {
+	return term.Batch(d.layout.Init(), term.Tick(time.Second, nil))
+}
+
+func (d *dashboard) Update(msg term.Msg) (term.Model, term.Cmd) {
+	if _, ok := msg.(term.TickMsg); ok {
+		d.ticks++
+		for i, s := range symbols {
+			d.update(s, 100.0+rand.Float64()*10.0)
+			d.hm.AddValue(i, rand.Intn(5))
+		}
+		// the viewer works on a copy so it has to show the changed prices
+		d.viewer.Refresh()
+		_, cmd := d.layout.Update(msg)
+		return d, term.Batch(cmd, term.Tick(time.Second, nil))
+	}
+	if size, ok := msg.(term.WindowSizeMsg); ok {
+		// leave room for the title
+		size.Height -= 2
+		msg = size
+	}
+	_, cmd := d.layout.Update(msg)
+	return d, cmd
+}
+
+func (d *dashboard) View() string {
+	return fmt.Sprintf("Updates: %d (tab switches focus, q quits)\n\n%s", d.ticks, d.layout.View())
+}
+
+func main() {
+	if _, err := term.Run(newDashboard(), term.WithAltScreen()); err != nil {
+		fmt.Println(err)
+	}
+}
@@ -75,4 +75,27 @@ func main() {
 		},
 	}
 	fmt.Println(g)
+
+	dashboard := term.Grid{
+		Width: 100,
+		Gap:   1,
+		Rows: []term.GridRow{
+			{
+				Cells: []term.GridCell{
+					{Content: tbl, Plain: true, Border: table.RoundedBorder, Title: "First"},
+					{Flex: 1, Content: tbl2, Plain: true, Align: term.AlignCenter, Border: table.RoundedBorder, Title: "Second", TitleAlign: term.AlignCenter},
+					{Percent: 20, Style: st, Text: "Bottom", VAlign: term.AlignBottom, Border: table.DefaultBorder, BorderStyle: st2},
+				},
+			},
+		},
+	}
+	fmt.Println(dashboard)
+
+	panel := term.NewContentPanel("Second", tbl2)
+	panel.Footer = "8 rows"
+	panel.FooterAlign = term.AlignRight
+	panel.Border = table.RoundedBorder
+	panel.BorderStyle = st2
+	panel.TitleStyle = st
+	fmt.Println(panel)
 }
@@ -240,7 +240,10 @@ func (hm *HeatMap) String() string {
 			me = len(l.Entries)
 		}
 	}
-	q := hm.recent / hm.delimiter
+	q := 0
+	if hm.delimiter > 0 {
+		q = hm.recent / hm.delimiter
+	}
 	if hm.recent > 0 {
 		me = hm.recent*(hm.padding+1) + q*(hm.padding+1)
 	}
@@ -328,6 +331,12 @@ func (hm *HeatMap) String() string {
 	return sb.String()
 }
 
+// ConvertHeatMap draws the heatmap with its colors at the position of the
+// canvas. It returns the width and the height of the heatmap.
+func ConvertHeatMap(c *term.Canvas, x, y int, hm *HeatMap) (int, int) {
+	return c.Draw(x, y, hm)
+}
+
 type HeatmapHtmlRenderer interface {
 	Start() string
 	StartRow() string
@@ -0,0 +1,197 @@
+// Package series calculates rolling window statistics and technical
+// indicators. All functions return a slice with the same length as the
+// input. Values within the warmup period are NaN.
+package series
+
+import "math"
+
+func nans(n int) []float64 {
+	ret := make([]float64, n)
+	for i := range ret {
+		ret[i] = math.NaN()
+	}
+	return ret
+}
+
+// SMA is the simple moving average over period values. NaN values at the
+// beginning are skipped.
+func SMA(values []float64, period int) []float64 {
+	ret := nans(len(values))
+	if period <= 0 {
+		return ret
+	}
+	start := 0
+	for start < len(values) && math.IsNaN(values[start]) {
+		start++
+	}
+	sum := 0.0
+	for i := start; i < len(values); i++ {
+		sum += values[i]
+		if i >= start+period {
+			sum -= values[i-period]
+		}
+		if i >= start+period-1 {
+			ret[i] = sum / float64(period)
+		}
+	}
+	return ret
+}
+
+// EMA is the exponential moving average. It starts with the SMA of the
+// first period values.
+func EMA(values []float64, period int) []float64 {
+	return smooth(values, period, 2.0/float64(period+1))
+}
+
+// smooth applies exponential smoothing with factor k starting with the
+// average of the first period values. NaN values at the beginning are skipped.
+func smooth(values []float64, period int, k float64) []float64 {
+	ret := nans(len(values))
+	if period <= 0 {
+		return ret
+	}
+	start := 0
+	for start < len(values) && math.IsNaN(values[start]) {
+		start++
+	}
+	if start+period > len(values) {
+		return ret
+	}
+	sum := 0.0
+	for i := start; i < start+period; i++ {
+		sum += values[i]
+	}
+	prev := sum / float64(period)
+	ret[start+period-1] = prev
+	for i := start + period; i < len(values); i++ {
+		prev = values[i]*k + prev*(1.0-k)
+		ret[i] = prev
+	}
+	return ret
+}
+
+// RollingStdDev is the population standard deviation over period values
+func RollingStdDev(values []float64, period int) []float64 {
+	ret := nans(len(values))
+	mean := SMA(values, period)
+	for i := period - 1; i < len(values) && period > 0; i++ {
+		sq := 0.0
+		for j := i - period + 1; j <= i; j++ {
+			sq += (values[j] - mean[i]) * (values[j] - mean[i])
+		}
+		ret[i] = math.Sqrt(sq / float64(period))
+	}
+	return ret
+}
+
+// RollingHigh is the highest value of the last period values
+func RollingHigh(values []float64, period int) []float64 {
+	return rolling(values, period, math.Max)
+}
+
+// RollingLow is the lowest value of the last period values
+func RollingLow(values []float64, period int) []float64 {
+	return rolling(values, period, math.Min)
+}
+
+func rolling(values []float64, period int, fn func(a, b float64) float64) []float64 {
+	ret := nans(len(values))
+	for i := period - 1; i < len(values) && period > 0; i++ {
+		v := values[i]
+		for j := i - period + 1; j < i; j++ {
+			v = fn(v, values[j])
+		}
+		ret[i] = v
+	}
+	return ret
+}
+
+// Bollinger returns the upper, middle and lower band. The middle band is
+// the SMA and the bands are k standard deviations away.
+func Bollinger(values []float64, period int, k float64) ([]float64, []float64, []float64) {
+	middle := SMA(values, period)
+	sd := RollingStdDev(values, period)
+	upper := make([]float64, len(values))
+	lower := make([]float64, len(values))
+	for i := range values {
+		upper[i] = middle[i] + k*sd[i]
+		lower[i] = middle[i] - k*sd[i]
+	}
+	return upper, middle, lower
+}
+
+// RSI is the relative strength index (0 - 100) using Wilder's smoothing
+func RSI(values []float64, period int) []float64 {
+	ret := nans(len(values))
+	if period <= 0 || len(values) <= period {
+		return ret
+	}
+	gain := 0.0
+	loss := 0.0
+	for i := 1; i <= period; i++ {
+		d := values[i] - values[i-1]
+		if d > 0.0 {
+			gain += d
+		} else {
+			loss -= d
+		}
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+	ret[period] = rsi(gain, loss)
+	for i := period + 1; i < len(values); i++ {
+		d := values[i] - values[i-1]
+		g := math.Max(d, 0.0)
+		l := math.Max(-d, 0.0)
+		gain = (gain*float64(period-1) + g) / float64(period)
+		loss = (loss*float64(period-1) + l) / float64(period)
+		ret[i] = rsi(gain, loss)
+	}
+	return ret
+}
+
+func rsi(gain, loss float64) float64 {
+	if loss == 0.0 {
+		if gain == 0.0 {
+			return 50.0
+		}
+		return 100.0
+	}
+	return 100.0 - 100.0/(1.0+gain/loss)
+}
+
+// TrueRange is the greatest of high - low and the distances of high and
+// low to the previous close
+func TrueRange(high, low, close []float64) []float64 {
+	ret := make([]float64, len(close))
+	for i := range close {
+		ret[i] = high[i] - low[i]
+		if i > 0 {
+			ret[i] = math.Max(ret[i], math.Abs(high[i]-close[i-1]))
+			ret[i] = math.Max(ret[i], math.Abs(low[i]-close[i-1]))
+		}
+	}
+	return ret
+}
+
+// ATR is the average true range using Wilder's smoothing
+func ATR(high, low, close []float64, period int) []float64 {
+	return smooth(TrueRange(high, low, close), period, 1.0/float64(period))
+}
+
+// MACD returns the MACD line (fast EMA - slow EMA), the signal line and
+// the histogram
+func MACD(values []float64, fast, slow, signal int) ([]float64, []float64, []float64) {
+	f := EMA(values, fast)
+	s := EMA(values, slow)
+	line := make([]float64, len(values))
+	for i := range values {
+		line[i] = f[i] - s[i]
+	}
+	sig := smooth(line, signal, 2.0/float64(signal+1))
+	histo := make([]float64, len(values))
+	for i := range values {
+		histo[i] = line[i] - sig[i]
+	}
+	return line, sig, histo
+}
@@ -0,0 +1,76 @@
+package series
+
+import (
+	"math"
+	"testing"
+)
+
+var nan = math.NaN()
+
+func equalValues(t *testing.T, name string, expected, got []float64) {
+	t.Helper()
+	if len(expected) != len(got) {
+		t.Fatalf("%s: expected %d values but got %d", name, len(expected), len(got))
+	}
+	for i := range expected {
+		if math.IsNaN(expected[i]) && math.IsNaN(got[i]) {
+			continue
+		}
+		if math.Abs(expected[i]-got[i]) > 1e-9 {
+			t.Errorf("%s: expected %v at %d but got %v", name, expected[i], i, got[i])
+		}
+	}
+}
+
+func TestSMA(t *testing.T) {
+	equalValues(t, "sma", []float64{nan, nan, 2, 3, 5}, SMA([]float64{1, 2, 3, 4, 8}, 3))
+	equalValues(t, "period 0", []float64{nan, nan}, SMA([]float64{1, 2}, 0))
+	equalValues(t, "too short", []float64{nan, nan}, SMA([]float64{1, 2}, 3))
+	equalValues(t, "warmup", []float64{nan, nan, nan, 2.5, 3.5}, SMA([]float64{nan, nan, 2, 3, 4}, 2))
+}
+
+func TestEMA(t *testing.T) {
+	equalValues(t, "ema", []float64{nan, 3, 5, 7, 31.0 / 3.0}, EMA([]float64{2, 4, 6, 8, 12}, 2))
+	equalValues(t, "too short", []float64{nan, nan}, EMA([]float64{1, 2}, 3))
+}
+
+func TestRollingStats(t *testing.T) {
+	values := []float64{3, 1, 4, 1, 5}
+	equalValues(t, "high", []float64{nan, 3, 4, 4, 5}, RollingHigh(values, 2))
+	equalValues(t, "low", []float64{nan, nan, 1, 1, 1}, RollingLow(values, 3))
+	equalValues(t, "stddev", []float64{nan, 1, 1.5, 1.5, 2}, RollingStdDev(values, 2))
+}
+
+func TestRSI(t *testing.T) {
+	equalValues(t, "rsi", []float64{nan, nan, 50, 75, 87.5}, RSI([]float64{1, 2, 1, 2, 3}, 2))
+	equalValues(t, "only gains", []float64{nan, nan, 100}, RSI([]float64{1, 2, 3}, 2))
+	equalValues(t, "flat", []float64{nan, nan, 50}, RSI([]float64{1, 1, 1}, 2))
+	equalValues(t, "too short", []float64{nan, nan}, RSI([]float64{1, 2}, 2))
+}
+
+func TestATR(t *testing.T) {
+	high := []float64{10, 11, 12}
+	low := []float64{8, 9, 9}
+	close := []float64{9, 10, 11}
+	equalValues(t, "true range", []float64{2, 2, 3}, TrueRange(high, low, close))
+	equalValues(t, "atr", []float64{nan, 2, 2.5}, ATR(high, low, close, 2))
+}
+
+func TestBollinger(t *testing.T) {
+	upper, middle, lower := Bollinger([]float64{1, 2, 3, 4}, 2, 2)
+	equalValues(t, "upper", []float64{nan, 2.5, 3.5, 4.5}, upper)
+	equalValues(t, "middle", []float64{nan, 1.5, 2.5, 3.5}, middle)
+	equalValues(t, "lower", []float64{nan, 0.5, 1.5, 2.5}, lower)
+}
+
+func TestMACD(t *testing.T) {
+	line, sig, histo := MACD([]float64{1, 2, 3, 4, 6, 6}, 1, 2, 2)
+	// the slow EMA is 1.5, 2.5, 3.5, 5.166.. and 5.722..
+	equalValues(t, "line", []float64{nan, 0.5, 0.5, 0.5, 6 - 31.0/6.0, 6 - 103.0/18.0}, line)
+	equalValues(t, "signal", []float64{nan, nan, 0.5, 0.5, 13.0 / 18.0, 23.0 / 54.0}, sig)
+	for i := range histo {
+		if !math.IsNaN(sig[i]) && math.Abs(histo[i]-(line[i]-sig[i])) > 1e-9 {
+			t.Errorf("expected the histogram to be the difference at %d", i)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package series
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/amecky/table/table"
+)
+
+// Column returns the values of the column. Rows without a cell in that column have the value 0
+// and cells without a value like the warmup period of another indicator are NaN.
+func Column(t *table.Table, name string) ([]float64, error) {
+	idx := t.FindColumnIndex(name)
+	if idx == -1 {
+		return nil, fmt.Errorf("unknown column %q", name)
+	}
+	ret := make([]float64, len(t.Rows))
+	for i, r := range t.Rows {
+		if idx < len(r.Cells) {
+			ret[i] = r.Cells[idx].Value
+			if !r.Cells[idx].HasValue() {
+				ret[i] = math.NaN()
+			}
+		}
+	}
+	return ret, nil
+}
+
+// SkipNaN wraps a formatter so that NaN values of the warmup period are left empty
+func SkipNaN(fn table.FormatterFn) table.FormatterFn {
+	return func(values []float64, index int) (string, int, int) {
+		if math.IsNaN(values[index]) {
+			return "", 0, 1
+		}
+		return fn(values, index)
+	}
+}
+
+// Indicator adds a column calculated from the source column
+func Indicator(t *table.Table, source, name string, fn table.FormatterFn, calc func(values []float64) []float64) error {
+	values, err := Column(t, source)
+	if err != nil {
+		return err
+	}
+	t.AddColumn(name, calc(values), SkipNaN(fn))
+	return nil
+}
+
+// AddSMA adds the simple moving average of the source column
+func AddSMA(t *table.Table, source, name string, period int) error {
+	return Indicator(t, source, name, t.Formatters.Float, func(values []float64) []float64 {
+		return SMA(values, period)
+	})
+}
+
+// AddEMA adds the exponential moving average of the source column
+func AddEMA(t *table.Table, source, name string, period int) error {
+	return Indicator(t, source, name, t.Formatters.Float, func(values []float64) []float64 {
+		return EMA(values, period)
+	})
+}
+
+// AddRSI adds the RSI of the source column as categorized values
+func AddRSI(t *table.Table, source, name string, period int) error {
+	return Indicator(t, source, name, t.Formatters.Categorized, func(values []float64) []float64 {
+		return RSI(values, period)
+	})
+}
+
+// AddRollingHigh adds the highest value of the last period rows
+func AddRollingHigh(t *table.Table, source, name string, period int) error {
+	return Indicator(t, source, name, t.Formatters.Float, func(values []float64) []float64 {
+		return RollingHigh(values, period)
+	})
+}
+
+// AddRollingLow adds the lowest value of the last period rows
+func AddRollingLow(t *table.Table, source, name string, period int) error {
+	return Indicator(t, source, name, t.Formatters.Float, func(values []float64) []float64 {
+		return RollingLow(values, period)
+	})
+}
+
+// AddATR adds the average true range of the high, low and close columns
+func AddATR(t *table.Table, high, low, close, name string, period int) error {
+	h, err := Column(t, high)
+	if err != nil {
+		return err
+	}
+	l, err := Column(t, low)
+	if err != nil {
+		return err
+	}
+	c, err := Column(t, close)
+	if err != nil {
+		return err
+	}
+	t.AddColumn(name, ATR(h, l, c, period), SkipNaN(t.Formatters.Float))
+	return nil
+}
+
+// AddBollinger adds the columns "<prefix> Upper", "<prefix> Middle" and "<prefix> Lower"
+func AddBollinger(t *table.Table, source, prefix string, period int, k float64) error {
+	values, err := Column(t, source)
+	if err != nil {
+		return err
+	}
+	upper, middle, lower := Bollinger(values, period, k)
+	fn := SkipNaN(t.Formatters.Float)
+	t.AddColumn(prefix+" Upper", upper, fn)
+	t.AddColumn(prefix+" Middle", middle, fn)
+	t.AddColumn(prefix+" Lower", lower, fn)
+	return nil
+}
+
+// AddMACD adds the columns "MACD", "Signal" and "Histo". The histogram
+// uses the Histo formatter.
+func AddMACD(t *table.Table, source string, fast, slow, signal int) error {
+	values, err := Column(t, source)
+	if err != nil {
+		return err
+	}
+	line, sig, histo := MACD(values, fast, slow, signal)
+	t.AddColumn("MACD", line, SkipNaN(t.Formatters.Float))
+	t.AddColumn("Signal", sig, SkipNaN(t.Formatters.Float))
+	t.AddColumn("Histo", histo, SkipNaN(t.Formatters.Histo))
+	return nil
+}
@@ -0,0 +1,46 @@
+package series
+
+import (
+	"math"
+	"testing"
+
+	"github.com/amecky/table/table"
+)
+
+func TestAddSMA(t *testing.T) {
+	tbl := table.New().Headers("Close")
+	for _, v := range []float64{1, 2, 3, 4} {
+		tbl.CreateRow().AddFloat(v, 0)
+	}
+	if err := AddSMA(tbl, "Close", "SMA", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddSMA(tbl, "Missing", "SMA", 3); err == nil {
+		t.Error("expected an error for the unknown column")
+	}
+	for i, r := range tbl.Rows {
+		c := r.Cells[1]
+		if math.IsNaN(c.Value) {
+			t.Errorf("expected no NaN value in row %d", i)
+		}
+		if c.HasValue() != (i >= 2) {
+			t.Errorf("unexpected value %v in row %d", c.HasValue(), i)
+		}
+	}
+	if txt := tbl.Rows[0].Cells[1].Text; txt != "" {
+		t.Errorf("expected an empty warmup cell but got %q", txt)
+	}
+	if v := tbl.Rows[3].Cells[1].Value; v != 3 {
+		t.Errorf("expected 3 but got %v", v)
+	}
+	if cs := table.NewColumnStats(tbl.ColumnValues(1)); cs.Min != 2 {
+		t.Errorf("expected the warmup period to be left out but got min %v", cs.Min)
+	}
+	// indicators of indicators skip the warmup period of the source
+	if err := AddSMA(tbl, "SMA", "SMA2", 2); err != nil {
+		t.Fatal(err)
+	}
+	if c := tbl.Rows[3].Cells[2]; !c.HasValue() || c.Value != 2.5 {
+		t.Errorf("expected 2.5 but got %+v", c)
+	}
+}
@@ -0,0 +1,194 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+const (
+	// BarLabelNone shows only the bar
+	BarLabelNone = iota
+	// BarLabelBeside shows the value right of the bar
+	BarLabelBeside
+	// BarLabelOverlay writes the value into the center of the bar
+	BarLabelOverlay
+)
+
+// eighth blocks from 1/8 to 8/8
+var barBlocks = []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉", "█"}
+
+// BarOptions define how a data bar is drawn. If Fixed is false the
+// range is taken from all values of the column at render time.
+type BarOptions struct {
+	Width  int
+	Min    float64
+	Max    float64
+	Fixed  bool
+	Label  int
+	Format string
+}
+
+var DefaultBarOptions = BarOptions{
+	Width:  10,
+	Label:  BarLabelNone,
+	Format: "%.2f",
+}
+
+// FixedRange returns a copy of the options using the given range
+func (o BarOptions) FixedRange(min, max float64) BarOptions {
+	o.Min = min
+	o.Max = max
+	o.Fixed = true
+	return o
+}
+
+// WithLabel returns a copy of the options using the given label mode
+func (o BarOptions) WithLabel(label int) BarOptions {
+	o.Label = label
+	return o
+}
+
+// render draws the bar of the value within the range. A NaN value is
+// drawn as an empty bar and infinite values are clamped to the range.
+func (o BarOptions) render(v, min, max float64) string {
+	if o.Fixed {
+		min = o.Min
+		max = o.Max
+	}
+	width := o.Width
+	if width <= 0 {
+		width = DefaultBarOptions.Width
+	}
+	if min > 0.0 || !isFinite(min) {
+		min = 0.0
+	}
+	if max < 0.0 || !isFinite(max) {
+		max = 0.0
+	}
+	label := v
+	if math.IsNaN(v) {
+		v = 0.0
+	}
+	v = math.Max(min, math.Min(max, v))
+	negWidth := 0
+	if min < 0.0 && max > min {
+		negWidth = int(math.Round(float64(width) * -min / (max - min)))
+	}
+	posWidth := width - negWidth
+
+	sb := strings.Builder{}
+	if negWidth > 0 {
+		neg := ""
+		if v < 0.0 {
+			halves := int(math.Round(v / min * float64(negWidth) * 2.0))
+			if halves > negWidth*2 {
+				halves = negWidth * 2
+			}
+			neg = strings.Repeat("█", halves/2)
+			if halves%2 == 1 {
+				neg = "▐" + neg
+			}
+		}
+		sb.WriteString(strings.Repeat(" ", negWidth-internalLen(neg)))
+		sb.WriteString(neg)
+		sb.WriteString("│")
+	}
+	if posWidth > 0 {
+		pos := ""
+		if v > 0.0 && max > 0.0 {
+			eighths := int(math.Round(v / max * float64(posWidth) * 8.0))
+			if eighths > posWidth*8 {
+				eighths = posWidth * 8
+			}
+			pos = strings.Repeat("█", eighths/8)
+			if eighths%8 > 0 {
+				pos += barBlocks[eighths%8-1]
+			}
+		}
+		sb.WriteString(pos)
+		sb.WriteString(strings.Repeat(" ", posWidth-internalLen(pos)))
+	}
+	bar := sb.String()
+
+	format := o.Format
+	if format == "" {
+		format = DefaultBarOptions.Format
+	}
+	switch o.Label {
+	case BarLabelBeside:
+		return bar + " " + fmt.Sprintf(format, label)
+	case BarLabelOverlay:
+		runes := []rune(bar)
+		label := []rune(fmt.Sprintf(format, label))
+		start := (len(runes) - len(label)) / 2
+		if start < 0 {
+			start = 0
+		}
+		for i, r := range label {
+			if start+i < len(runes) {
+				runes[start+i] = r
+			}
+		}
+		return string(runes)
+	}
+	return bar
+}
+
+func barMarker(v float64) int {
+	if v < 0.0 {
+		return MarkerClassA
+	}
+	return MarkerClassE
+}
+
+// AddBar adds a horizontal bar proportional to the value. The
+// text of the cell is created when the table is rendered.
+func (tr *Row) AddBar(v float64, opts BarOptions) *Row {
+	o := opts
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      o.render(v, v, v),
+		Marker:    barMarker(v),
+		Alignment: AlignLeft,
+		Value:     v,
+		bar:       &o,
+	})
+	return tr
+}
+
+// AddProgress adds a bar showing the value as fraction of 0 - max
+// with the percentage beside it
+func (tr *Row) AddProgress(v, max float64, width int) *Row {
+	o := DefaultBarOptions.FixedRange(0.0, max).WithLabel(BarLabelNone)
+	o.Width = width
+	pct := 0.0
+	if max != 0.0 {
+		pct = v / max * 100.0
+	}
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      o.render(v, 0.0, max) + fmt.Sprintf(" %3.0f%%", pct),
+		Marker:    MarkerClassC,
+		Alignment: AlignLeft,
+		Value:     v,
+	})
+	return tr
+}
+
+// BarFormatter draws bars for AddColumn using the range of all values.
+// The range is computed once for all cells of a column.
+func BarFormatter(opts BarOptions) FormatterFn {
+	var mu sync.Mutex
+	var last []float64
+	var min, max float64
+	return func(values []float64, index int) (string, int, int) {
+		mu.Lock()
+		if len(last) != len(values) || &last[0] != &values[0] {
+			stats := NewColumnStats(values)
+			last, min, max = values, stats.Min, stats.Max
+		}
+		mu.Unlock()
+		v := values[index]
+		return opts.render(v, min, max), barMarker(v), int(AlignLeft)
+	}
+}
@@ -0,0 +1,71 @@
+package table
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBarRender(t *testing.T) {
+	opts := DefaultBarOptions
+	opts.Width = 4
+	tests := []struct {
+		name     string
+		v        float64
+		min, max float64
+		expected string
+	}{
+		{"empty", 0, 0, 8, "    "},
+		{"full", 8, 0, 8, "████"},
+		{"half", 4, 0, 8, "██  "},
+		{"eighths", 1, 0, 8, "▌   "},
+		{"negative", -4, -4, 4, "██│  "},
+		{"positive", 2, -4, 4, "  │█ "},
+		{"nan", math.NaN(), 0, 8, "    "},
+		{"inf", math.Inf(1), 0, 8, "████"},
+		{"negative inf", math.Inf(-1), -4, 4, "██│  "},
+		{"infinite range", 4, 0, math.Inf(1), "    "},
+	}
+	for _, tc := range tests {
+		if got := opts.render(tc.v, tc.min, tc.max); got != tc.expected {
+			t.Errorf("%s: expected %q but got %q", tc.name, tc.expected, got)
+		}
+	}
+	if got := opts.WithLabel(BarLabelBeside).render(math.NaN(), 0, 8); got != "     NaN" {
+		t.Errorf("expected the NaN label but got %q", got)
+	}
+}
+
+func TestBarColumnIgnoresNonFiniteValues(t *testing.T) {
+	opts := DefaultBarOptions
+	opts.Width = 4
+	tbl := New().Headers("Value")
+	for _, v := range []float64{2, math.NaN(), 8, math.Inf(1)} {
+		tbl.CreateRow().AddBar(v, opts)
+	}
+	rows := tbl.view(false)
+	expected := []string{"█   ", "    ", "████", "████"}
+	for i, r := range rows {
+		if r.Cells[0].Text != expected[i] {
+			t.Errorf("row %d: expected %q but got %q", i, expected[i], r.Cells[0].Text)
+		}
+	}
+	fn := BarFormatter(opts)
+	values := []float64{2, math.NaN(), 8, math.Inf(1)}
+	for i := range values {
+		if txt, _, _ := fn(values, i); txt != expected[i] {
+			t.Errorf("formatter %d: expected %q but got %q", i, expected[i], txt)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	tbl := New().Headers("Done")
+	tbl.CreateRow().AddProgress(5, 10, 4)
+	if got := tbl.Rows[0].Cells[0].Text; got != "██    50%" {
+		t.Errorf("unexpected progress %q", got)
+	}
+	if !strings.Contains(tbl.Plain(), "██    50%") {
+		t.Errorf("expected the progress in\n%s", tbl.Plain())
+	}
+}
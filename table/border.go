@@ -1,126 +1,16 @@
 package table
 
-type Border struct {
-	Size      int
-	H_LINE    string
-	V_LINE    string
-	TL_CORNER string
-	TR_CORNER string
-	BR_CORNER string
-	BL_CORNER string
-	CROSS     string
-	TOP_DEL   string
-	BOT_DEL   string
-	LEFT_DEL  string
-	RIGHT_DEL string
-}
+import "github.com/amecky/table/term"
 
-var DefaultBorder = Border{
-	Size:      1,
-	H_LINE:    "│",
-	V_LINE:    "─",
-	TL_CORNER: "┌",
-	TR_CORNER: "┐",
-	BR_CORNER: "┘",
-	BL_CORNER: "└",
-	CROSS:     "┼",
-	TOP_DEL:   "┬",
-	BOT_DEL:   "┴",
-	LEFT_DEL:  "├",
-	RIGHT_DEL: "┤",
-}
+// Border is defined in the term package so grids and panels can use the same styles
+type Border = term.Border
 
-var HiddenBorder = Border{
-	Size:      0,
-	H_LINE:    "",
-	V_LINE:    "─",
-	TL_CORNER: "",
-	TR_CORNER: "",
-	BR_CORNER: "",
-	BL_CORNER: "",
-	CROSS:     "",
-	TOP_DEL:   "",
-	BOT_DEL:   "",
-	LEFT_DEL:  "",
-	RIGHT_DEL: "",
-}
+var DefaultBorder = term.DefaultBorder
 
-var RoundedBorder = Border{
-	Size:      1,
-	V_LINE:    "─",
-	H_LINE:    "│",
-	TL_CORNER: "╭",
-	TR_CORNER: "╮",
-	BL_CORNER: "╰",
-	BR_CORNER: "╯",
-	LEFT_DEL:  "├",
-	RIGHT_DEL: "┤",
-	CROSS:     "┼",
-	TOP_DEL:   "┬",
-	BOT_DEL:   "┴",
-}
+var HiddenBorder = term.HiddenBorder
 
-var ThickBorder = Border{
-	Size:      1,
-	V_LINE:    "━",
-	H_LINE:    "┃",
-	TL_CORNER: "┏",
-	TR_CORNER: "┓",
-	BL_CORNER: "┗",
-	BR_CORNER: "┛",
-	LEFT_DEL:  "┣",
-	RIGHT_DEL: "┫",
-	CROSS:     "╋",
-	TOP_DEL:   "┳",
-	BOT_DEL:   "┻",
-}
+var RoundedBorder = term.RoundedBorder
 
-var DoubleBorder = Border{
-	Size:      1,
-	V_LINE:    "═",
-	H_LINE:    "║",
-	TL_CORNER: "╔",
-	TR_CORNER: "╗",
-	BL_CORNER: "╚",
-	BR_CORNER: "╝",
-	LEFT_DEL:  "╠",
-	RIGHT_DEL: "╣",
-	CROSS:     "╬",
-	TOP_DEL:   "╦",
-	BOT_DEL:   "╩",
-}
+var ThickBorder = term.ThickBorder
 
-/*
-blockBorder = Border{
-	Top:         "█",
-	Bottom:      "█",
-	Left:        "█",
-	Right:       "█",
-	TopLeft:     "█",
-	TopRight:    "█",
-	BottomLeft:  "█",
-	BottomRight: "█",
-}
-
-outerHalfBlockBorder = Border{
-	Top:         "▀",
-	Bottom:      "▄",
-	Left:        "▌",
-	Right:       "▐",
-	TopLeft:     "▛",
-	TopRight:    "▜",
-	BottomLeft:  "▙",
-	BottomRight: "▟",
-}
-
-innerHalfBlockBorder = Border{
-	Top:         "▄",
-	Bottom:      "▀",
-	Left:        "▐",
-	Right:       "▌",
-	TopLeft:     "▗",
-	TopRight:    "▖",
-	BottomLeft:  "▝",
-	BottomRight: "▘",
-}
-*/
+var DoubleBorder = term.DoubleBorder
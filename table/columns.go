@@ -0,0 +1,185 @@
+package table
+
+import "fmt"
+
+func (rt *Table) columnIndex(name string) (int, error) {
+	idx := rt.FindColumnIndex(name)
+	if idx == -1 {
+		return -1, fmt.Errorf("unknown column %q", name)
+	}
+	return idx, nil
+}
+
+// RenameColumn renames the column. Computed columns referencing it are updated.
+func (rt *Table) RenameColumn(old, name string) error {
+	idx, err := rt.columnIndex(old)
+	if err != nil {
+		return err
+	}
+	if old != name && rt.FindColumnIndex(name) != -1 {
+		return fmt.Errorf("column %q already exists", name)
+	}
+	rt.TableHeaders[idx].Text = name
+	for i, h := range rt.TableHeaders {
+		if h.Expr != nil {
+			rt.TableHeaders[i].Expr = h.Expr.rename(old, name)
+		}
+	}
+	return nil
+}
+
+// MoveColumn moves the column to the given position
+func (rt *Table) MoveColumn(name string, to int) error {
+	idx, err := rt.columnIndex(name)
+	if err != nil {
+		return err
+	}
+	if err := rt.checkColumn(to); err != nil {
+		return err
+	}
+	rt.padRows()
+	h := rt.TableHeaders[idx]
+	rt.TableHeaders = append(rt.TableHeaders[:idx], rt.TableHeaders[idx+1:]...)
+	rt.TableHeaders = append(rt.TableHeaders[:to], append([]TableHeader{h}, rt.TableHeaders[to:]...)...)
+	for _, r := range rt.Rows {
+		c := r.Cells[idx]
+		cells := append(append([]Cell(nil), r.Cells[:idx]...), r.Cells[idx+1:]...)
+		r.Cells = append(cells[:to], append([]Cell{c}, cells[to:]...)...)
+	}
+	return nil
+}
+
+// RemoveColumn removes the column and the cells of all rows. Columns which
+// are used by computed columns cannot be removed.
+func (rt *Table) RemoveColumn(name string) error {
+	idx, err := rt.columnIndex(name)
+	if err != nil {
+		return err
+	}
+	for _, h := range rt.TableHeaders {
+		if h.Expr == nil {
+			continue
+		}
+		for _, c := range h.Expr.Columns() {
+			if c == name {
+				return fmt.Errorf("column %q is used by computed column %q", name, h.Text)
+			}
+		}
+	}
+	rt.padRows()
+	rt.TableHeaders = append(rt.TableHeaders[:idx], rt.TableHeaders[idx+1:]...)
+	for _, r := range rt.Rows {
+		r.Cells = append(append([]Cell(nil), r.Cells[:idx]...), r.Cells[idx+1:]...)
+	}
+	return nil
+}
+
+// DuplicateColumn inserts a copy of the column with the new name right after it
+func (rt *Table) DuplicateColumn(name, copyName string) error {
+	idx, err := rt.columnIndex(name)
+	if err != nil {
+		return err
+	}
+	if rt.FindColumnIndex(copyName) != -1 {
+		return fmt.Errorf("column %q already exists", copyName)
+	}
+	rt.padRows()
+	h := rt.TableHeaders[idx]
+	h.Text = copyName
+	h.Rules = append([]Rule(nil), h.Rules...)
+	pos := idx + 1
+	rt.TableHeaders = append(rt.TableHeaders[:pos], append([]TableHeader{h}, rt.TableHeaders[pos:]...)...)
+	for _, r := range rt.Rows {
+		cells := append([]Cell(nil), r.Cells[:pos]...)
+		cells = append(cells, r.Cells[idx])
+		r.Cells = append(cells, r.Cells[pos:]...)
+	}
+	return nil
+}
+
+// HideColumn keeps the column in the table and in exports like JSON but
+// it is not rendered
+func (rt *Table) HideColumn(name string) error {
+	idx, err := rt.columnIndex(name)
+	if err != nil {
+		return err
+	}
+	rt.TableHeaders[idx].Hidden = true
+	return nil
+}
+
+// ShowColumn renders a hidden column again
+func (rt *Table) ShowColumn(name string) error {
+	idx, err := rt.columnIndex(name)
+	if err != nil {
+		return err
+	}
+	rt.TableHeaders[idx].Hidden = false
+	return nil
+}
+
+// SelectColumns returns a new table containing only the given columns in
+// that order. Computed columns keep their current values.
+func (rt *Table) SelectColumns(names ...string) (*Table, error) {
+	indices := make([]int, len(names))
+	for i, n := range names {
+		idx, err := rt.columnIndex(n)
+		if err != nil {
+			return nil, err
+		}
+		indices[i] = idx
+	}
+	ret := rt.derive()
+	ret.TableHeaders = make([]TableHeader, len(indices))
+	for i, idx := range indices {
+		ret.TableHeaders[i] = rt.TableHeaders[idx]
+		ret.TableHeaders[i].Expr = nil
+	}
+	for _, r := range rt.Rows {
+		c := *r
+		c.Cells = project(r.Cells, indices)
+		c.table = ret
+		ret.Rows = append(ret.Rows, &c)
+	}
+	return ret, nil
+}
+
+func project(cells []Cell, indices []int) []Cell {
+	ret := make([]Cell, len(indices))
+	for i, idx := range indices {
+		if idx < len(cells) {
+			ret[i] = cells[idx]
+		}
+	}
+	return ret
+}
+
+// visibleColumns returns the indices of the columns which are not hidden
+func visibleColumns(headers []TableHeader) []int {
+	indices := make([]int, 0, len(headers))
+	for i, h := range headers {
+		if !h.Hidden {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// visible removes the hidden columns from headers and rows
+func visible(headers []TableHeader, rows []*Row) ([]TableHeader, []*Row) {
+	indices := visibleColumns(headers)
+	if len(indices) == len(headers) {
+		return headers, rows
+	}
+	vh := make([]TableHeader, len(indices))
+	for i, idx := range indices {
+		vh[i] = headers[idx]
+	}
+	vr := make([]*Row, len(rows))
+	for i, r := range rows {
+		c := *r
+		c.Cells = project(r.Cells, indices)
+		vr[i] = &c
+	}
+	return vh, vr
+}
@@ -0,0 +1,39 @@
+package table
+
+import "testing"
+
+func cellTexts(r *Row) string {
+	ret := ""
+	for _, c := range r.Cells {
+		ret += c.Text + ","
+	}
+	return ret
+}
+
+func TestColumnOperationsKeepSurplusCells(t *testing.T) {
+	tbl := raggedTable()
+	if err := tbl.DuplicateColumn("A", "C"); err != nil {
+		t.Fatal(err)
+	}
+	if got := cellTexts(tbl.Rows[0]); got != "x,x,," {
+		t.Errorf("unexpected first row %s", got)
+	}
+	if got := cellTexts(tbl.Rows[1]); got != "1,1,2,3," {
+		t.Errorf("unexpected second row %s", got)
+	}
+	if err := tbl.MoveColumn("B", 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := cellTexts(tbl.Rows[1]); got != "2,1,1,3," {
+		t.Errorf("unexpected second row after move %s", got)
+	}
+	if err := tbl.RemoveColumn("C"); err != nil {
+		t.Fatal(err)
+	}
+	if got := cellTexts(tbl.Rows[1]); got != "2,1,3," {
+		t.Errorf("unexpected second row after remove %s", got)
+	}
+	if got := cellTexts(tbl.Rows[0]); got != ",x," {
+		t.Errorf("unexpected first row after remove %s", got)
+	}
+}
@@ -0,0 +1,149 @@
+package table
+
+import "fmt"
+
+// FormatContext gives a formatter access to the whole column, the
+// column statistics and the other cells of the current row
+type FormatContext struct {
+	Table  *Table
+	Row    int
+	Column int
+	Values []float64
+	Stats  ColumnStats
+}
+
+// ContextFormatterFn returns text, marker and alignment like FormatterFn
+type ContextFormatterFn func(ctx FormatContext) (string, int, int)
+
+func (ctx FormatContext) Value() float64 {
+	return ctx.Values[ctx.Row]
+}
+
+// Prev returns the value of the previous row
+func (ctx FormatContext) Prev() (float64, bool) {
+	if ctx.Row > 0 && ctx.Row-1 < len(ctx.Values) {
+		return ctx.Values[ctx.Row-1], true
+	}
+	return 0.0, false
+}
+
+// Cell returns the cell of another column in the current row
+func (ctx FormatContext) Cell(column string) (Cell, bool) {
+	if ctx.Table == nil || ctx.Row >= len(ctx.Table.Rows) {
+		return Cell{}, false
+	}
+	idx := ctx.Table.FindColumnIndex(column)
+	r := ctx.Table.Rows[ctx.Row]
+	if idx == -1 || idx >= len(r.Cells) {
+		return Cell{}, false
+	}
+	return r.Cells[idx], true
+}
+
+// ColumnValue returns the value of another column in the current row
+func (ctx FormatContext) ColumnValue(column string) float64 {
+	c, _ := ctx.Cell(column)
+	return c.Value
+}
+
+// WithContext adapts a FormatterFn to a ContextFormatterFn
+func WithContext(fn FormatterFn) ContextFormatterFn {
+	return func(ctx FormatContext) (string, int, int) {
+		return fn(ctx.Values, ctx.Row)
+	}
+}
+
+// AddContextColumn works like AddColumn but the formatter receives a FormatContext
+func (rt *Table) AddContextColumn(name string, values []float64, fn ContextFormatterFn) {
+	rt.TableHeaders = append(rt.TableHeaders, TableHeader{Text: name, Marker: 0})
+	ctx := FormatContext{
+		Table:  rt,
+		Column: len(rt.TableHeaders) - 1,
+		Values: values,
+		Stats:  NewColumnStats(values),
+	}
+	if len(rt.Rows) == 0 {
+		for i := 0; i < len(values); i++ {
+			rt.CreateRow()
+		}
+	}
+	for i := 0; i < len(rt.Rows) && i < len(values); i++ {
+		ctx.Row = i
+		txt, mk, al := fn(ctx)
+		rt.Rows[i].AddAlignedValue(txt, values[i], mk, al)
+	}
+}
+
+// FormatColumn formats all cells of an existing column again using their values
+func (rt *Table) FormatColumn(column string, fn ContextFormatterFn) error {
+	idx := rt.FindColumnIndex(column)
+	if idx == -1 {
+		return fmt.Errorf("unknown column %q", column)
+	}
+	values := make([]float64, len(rt.Rows))
+	for i, r := range rt.Rows {
+		values[i] = cellValue(r, idx)
+	}
+	ctx := FormatContext{
+		Table:  rt,
+		Column: idx,
+		Values: values,
+		Stats:  NewColumnStats(values),
+	}
+	for i, r := range rt.Rows {
+		if idx >= len(r.Cells) {
+			continue
+		}
+		ctx.Row = i
+		txt, mk, al := fn(ctx)
+		c := &r.Cells[idx]
+		c.Text = txt
+		c.Marker = mk
+		c.Alignment = TextAlign(al)
+	}
+	return nil
+}
+
+// RankFormatter assigns the class markers A - E by the rank of the value within the column
+func RankFormatter(nf NumberFormat) ContextFormatterFn {
+	return func(ctx FormatContext) (string, int, int) {
+		v := ctx.Value()
+		idx := int(ctx.Stats.Rank(v) * 5.0)
+		if idx > 4 {
+			idx = 4
+		}
+		return nf.Format(v), MarkerClassA + idx, 1
+	}
+}
+
+// ZScoreFormatter marks values which are more than threshold standard
+// deviations away from the mean of the column
+func ZScoreFormatter(threshold float64, nf NumberFormat) ContextFormatterFn {
+	return func(ctx FormatContext) (string, int, int) {
+		v := ctx.Value()
+		z := ctx.Stats.ZScore(v)
+		marker := MarkerNone
+		if z >= threshold {
+			marker = MarkerPositive
+		} else if z <= -threshold {
+			marker = MarkerNegative
+		}
+		return nf.Format(v), marker, 1
+	}
+}
+
+// BenchmarkFormatter marks values above the value of the benchmark column
+// in the same row as positive and values below as negative
+func BenchmarkFormatter(benchmark string, nf NumberFormat) ContextFormatterFn {
+	return func(ctx FormatContext) (string, int, int) {
+		v := ctx.Value()
+		b, ok := ctx.Cell(benchmark)
+		marker := MarkerNone
+		if ok && v > b.Value {
+			marker = MarkerPositive
+		} else if ok && v < b.Value {
+			marker = MarkerNegative
+		}
+		return nf.Format(v), marker, 1
+	}
+}
@@ -0,0 +1,89 @@
+package table
+
+import "testing"
+
+func contextTable() *Table {
+	tbl := New().Headers("Name", "Index")
+	for i, v := range []float64{5, 10, 15, 20, 25} {
+		tbl.CreateRow().AddDefaultText(string(rune('a'+i))).AddFloat(v, 0)
+	}
+	return tbl
+}
+
+func columnMarkers(tbl *Table, column string) []int {
+	idx := tbl.FindColumnIndex(column)
+	ret := make([]int, len(tbl.Rows))
+	for i, r := range tbl.Rows {
+		ret[i] = r.Cells[idx].Marker
+	}
+	return ret
+}
+
+func TestContextFormatters(t *testing.T) {
+	nf := DefaultNumberFormat
+	tests := []struct {
+		name     string
+		values   []float64
+		fn       ContextFormatterFn
+		expected []int
+	}{
+		{"rank", []float64{1, 2, 3, 4, 5}, RankFormatter(nf), []int{MarkerClassA, MarkerClassB, MarkerClassC, MarkerClassD, MarkerClassE}},
+		{"rank reversed", []float64{50, 40, 30, 20, 10}, RankFormatter(nf), []int{MarkerClassE, MarkerClassD, MarkerClassC, MarkerClassB, MarkerClassA}},
+		{"zscore", []float64{0, 10, 10, 10, 20}, ZScoreFormatter(1.5, nf), []int{MarkerNegative, MarkerNone, MarkerNone, MarkerNone, MarkerPositive}},
+		{"zscore constant", []float64{3, 3, 3, 3, 3}, ZScoreFormatter(0.1, nf), []int{MarkerNone, MarkerNone, MarkerNone, MarkerNone, MarkerNone}},
+		{"benchmark", []float64{4, 10, 20, 20, 30}, BenchmarkFormatter("Index", nf), []int{MarkerNegative, MarkerNone, MarkerPositive, MarkerNone, MarkerPositive}},
+		{"benchmark unknown", []float64{4, 10, 20, 20, 30}, BenchmarkFormatter("Unknown", nf), []int{MarkerNone, MarkerNone, MarkerNone, MarkerNone, MarkerNone}},
+	}
+	for _, tc := range tests {
+		tbl := contextTable()
+		tbl.AddContextColumn("Value", tc.values, tc.fn)
+		got := columnMarkers(tbl, "Value")
+		for i := range got {
+			if got[i] != tc.expected[i] {
+				t.Errorf("%s: expected %v but got %v", tc.name, tc.expected, got)
+				break
+			}
+		}
+	}
+}
+
+func TestFormatContext(t *testing.T) {
+	tbl := contextTable()
+	var prev []float64
+	var others []float64
+	tbl.AddContextColumn("Value", []float64{1, 2, 3, 4, 5}, func(ctx FormatContext) (string, int, int) {
+		if p, ok := ctx.Prev(); ok {
+			prev = append(prev, p)
+		}
+		others = append(others, ctx.ColumnValue("Index"))
+		if ctx.Stats.Max != 5 || ctx.Column != 2 {
+			t.Errorf("unexpected context %+v", ctx)
+		}
+		return "", 0, 0
+	})
+	if len(prev) != 4 || prev[0] != 1 || prev[3] != 4 {
+		t.Errorf("unexpected previous values %v", prev)
+	}
+	if len(others) != 5 || others[4] != 25 {
+		t.Errorf("unexpected values of the other column %v", others)
+	}
+}
+
+func TestFormatColumn(t *testing.T) {
+	tbl := contextTable()
+	if err := tbl.FormatColumn("Unknown", RankFormatter(DefaultNumberFormat)); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+	if err := tbl.FormatColumn("Index", WithContext(tbl.Formatters.Int)); err != nil {
+		t.Fatal(err)
+	}
+	if got := tbl.Rows[4].Cells[1].Text; got != "25" {
+		t.Errorf("expected 25 but got %q", got)
+	}
+	if err := tbl.FormatColumn("Index", RankFormatter(DefaultNumberFormat)); err != nil {
+		t.Fatal(err)
+	}
+	if got := columnMarkers(tbl, "Index"); got[0] != MarkerClassA || got[4] != MarkerClassE {
+		t.Errorf("unexpected markers %v", got)
+	}
+}
@@ -0,0 +1,232 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+type DiffKind int
+
+const (
+	// DiffSame means the row exists in both tables with the same texts
+	DiffSame DiffKind = iota
+	// DiffAdded means the row only exists in the new table
+	DiffAdded
+	// DiffRemoved means the row only exists in the old table
+	DiffRemoved
+	// DiffChanged means at least one cell of the row differs
+	DiffChanged
+)
+
+// RowDiff describes the difference of the rows with the same key.
+// Changed contains the names of all columns with a different text.
+type RowDiff struct {
+	Key     string
+	Kind    DiffKind
+	Old     *Row
+	New     *Row
+	Changed []string
+}
+
+// DiffOptions controls the table created by DiffWith
+type DiffOptions struct {
+	// Delta adds a column with the difference after every numeric column
+	// with changes instead of showing the old text in the cell
+	Delta bool
+	// HideUnchanged removes all rows without differences
+	HideUnchanged bool
+}
+
+var DefaultDiffOptions = DiffOptions{}
+
+func textOf(r *Row, idx int) string {
+	if r != nil && idx >= 0 && idx < len(r.Cells) {
+		return r.Cells[idx].Text
+	}
+	return ""
+}
+
+func cellOf(r *Row, idx int) Cell {
+	if r != nil && idx >= 0 && idx < len(r.Cells) {
+		return r.Cells[idx]
+	}
+	return Cell{}
+}
+
+// DiffRows compares the rows of both tables identified by the text of the
+// key column. The columns are matched by name. Rows of the new table come
+// first in their order followed by the removed rows. Keys must be unique
+// in both tables.
+func DiffRows(old, new *Table, key string) ([]RowDiff, error) {
+	oi := old.FindColumnIndex(key)
+	if oi == -1 {
+		return nil, fmt.Errorf("unknown column %q in old table", key)
+	}
+	ni := new.FindColumnIndex(key)
+	if ni == -1 {
+		return nil, fmt.Errorf("unknown column %q in new table", key)
+	}
+	oldRows := make(map[string]*Row)
+	for _, r := range old.Rows {
+		k := textOf(r, oi)
+		if _, ok := oldRows[k]; ok {
+			return nil, fmt.Errorf("duplicate key %q in old table", k)
+		}
+		oldRows[k] = r
+	}
+	ret := make([]RowDiff, 0, len(new.Rows))
+	seen := make(map[string]bool)
+	for _, r := range new.Rows {
+		k := textOf(r, ni)
+		if seen[k] {
+			return nil, fmt.Errorf("duplicate key %q in new table", k)
+		}
+		seen[k] = true
+		o, ok := oldRows[k]
+		if !ok {
+			ret = append(ret, RowDiff{Key: k, Kind: DiffAdded, New: r})
+			continue
+		}
+		d := RowDiff{Key: k, Kind: DiffSame, Old: o, New: r}
+		for j, h := range new.TableHeaders {
+			if textOf(r, j) != textOf(o, old.FindColumnIndex(h.Text)) {
+				d.Changed = append(d.Changed, h.Text)
+			}
+		}
+		if len(d.Changed) > 0 {
+			d.Kind = DiffChanged
+		}
+		ret = append(ret, d)
+	}
+	for _, r := range old.Rows {
+		k := textOf(r, oi)
+		if !seen[k] {
+			ret = append(ret, RowDiff{Key: k, Kind: DiffRemoved, Old: r})
+		}
+	}
+	return ret, nil
+}
+
+// Diff creates a table with the columns of the new table marking added,
+// removed and changed rows using the default options
+func Diff(old, new *Table, key string) (*Table, error) {
+	return DiffWith(old, new, key, DefaultDiffOptions)
+}
+
+// DiffWith creates a table with the columns of the new table. Added rows use
+// MarkerAdded, removed rows MarkerRemoved and changed cells MarkerChanged.
+func DiffWith(old, new *Table, key string, opts DiffOptions) (*Table, error) {
+	diffs, err := DiffRows(old, new, key)
+	if err != nil {
+		return nil, err
+	}
+	headers := new.TableHeaders
+	delta := make(map[int]bool)
+	if opts.Delta {
+		for _, d := range diffs {
+			for _, name := range d.Changed {
+				j := new.FindColumnIndex(name)
+				if cellOf(d.New, j).Value != cellOf(d.Old, old.FindColumnIndex(name)).Value {
+					delta[j] = true
+				}
+			}
+		}
+	}
+	ret := new.derive()
+	ret.TableHeaders = nil
+	for j, h := range headers {
+		h.Expr = nil
+		ret.TableHeaders = append(ret.TableHeaders, h)
+		if delta[j] {
+			ret.TableHeaders = append(ret.TableHeaders, TableHeader{Text: "Δ " + h.Text, Align: AlignRight})
+		}
+	}
+	nf := new.NumberFormat.WithSign(SignAlways)
+	for _, d := range diffs {
+		if d.Kind == DiffSame && opts.HideUnchanged {
+			continue
+		}
+		row := ret.CreateKeyedRow(d.Key)
+		changed := make(map[string]bool)
+		for _, name := range d.Changed {
+			changed[name] = true
+		}
+		for j, h := range headers {
+			oc := cellOf(d.Old, old.FindColumnIndex(h.Text))
+			c := cellOf(d.New, j)
+			switch d.Kind {
+			case DiffAdded:
+				c.Marker = MarkerAdded
+			case DiffRemoved:
+				c = oc
+				c.Marker = MarkerRemoved
+			case DiffChanged:
+				if changed[h.Text] {
+					c.Marker = MarkerChanged
+					if !delta[j] {
+						c.Text = c.Text + " (" + oc.Text + ")"
+					}
+				}
+			}
+			row.Cells = append(row.Cells, c)
+			if delta[j] {
+				dc := Cell{Alignment: AlignRight}
+				if d.Kind == DiffChanged && changed[h.Text] {
+					dc.Value = c.Value - oc.Value
+					dc.Text = nf.Format(dc.Value)
+					dc.Marker = MarkerPositive
+					if dc.Value < 0.0 {
+						dc.Marker = MarkerNegative
+					}
+				}
+				row.Cells = append(row.Cells, dc)
+			}
+		}
+	}
+	return ret, nil
+}
+
+func diffLine(prefix string, r *Row, t *Table, headers []TableHeader) string {
+	cells := make([]string, len(headers))
+	for j, h := range headers {
+		cells[j] = textOf(r, t.FindColumnIndex(h.Text))
+	}
+	return prefix + " " + strings.Join(cells, " | ")
+}
+
+// UnifiedDiff returns the differences as text similar to a unified diff.
+// Unchanged rows are skipped and changed rows are shown as removed and added.
+func UnifiedDiff(old, new *Table, key string) (string, error) {
+	diffs, err := DiffRows(old, new, key)
+	if err != nil {
+		return "", err
+	}
+	oldName := old.Description
+	if oldName == "" {
+		oldName = "old"
+	}
+	newName := new.Description
+	if newName == "" {
+		newName = "new"
+	}
+	var sb strings.Builder
+	sb.WriteString("--- " + oldName + "\n")
+	sb.WriteString("+++ " + newName + "\n")
+	names := make([]string, len(new.TableHeaders))
+	for j, h := range new.TableHeaders {
+		names[j] = h.Text
+	}
+	sb.WriteString("@@ " + strings.Join(names, " | ") + " @@\n")
+	for _, d := range diffs {
+		switch d.Kind {
+		case DiffAdded:
+			sb.WriteString(diffLine("+", d.New, new, new.TableHeaders) + "\n")
+		case DiffRemoved:
+			sb.WriteString(diffLine("-", d.Old, old, new.TableHeaders) + "\n")
+		case DiffChanged:
+			sb.WriteString(diffLine("-", d.Old, old, new.TableHeaders) + "\n")
+			sb.WriteString(diffLine("+", d.New, new, new.TableHeaders) + "\n")
+		}
+	}
+	return sb.String(), nil
+}
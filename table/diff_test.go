@@ -0,0 +1,123 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func diffTables() (*Table, *Table) {
+	old := New().Headers("Name", "Price")
+	old.CreateRow().AddDefaultText("a").AddFloat(1, 0)
+	old.CreateRow().AddDefaultText("b").AddFloat(2, 0)
+	old.CreateRow().AddDefaultText("c").AddFloat(3, 0)
+	new := New().Headers("Name", "Price")
+	new.CreateRow().AddDefaultText("a").AddFloat(1, 0)
+	new.CreateRow().AddDefaultText("c").AddFloat(4, 0)
+	new.CreateRow().AddDefaultText("d").AddFloat(5, 0)
+	return old, new
+}
+
+func TestDiffRows(t *testing.T) {
+	old, new := diffTables()
+	diffs, err := DiffRows(old, new, "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		key  string
+		kind DiffKind
+	}{
+		{"a", DiffSame},
+		{"c", DiffChanged},
+		{"d", DiffAdded},
+		{"b", DiffRemoved},
+	}
+	if len(diffs) != len(expected) {
+		t.Fatalf("expected %d diffs but got %d", len(expected), len(diffs))
+	}
+	for i, e := range expected {
+		if diffs[i].Key != e.key || diffs[i].Kind != e.kind {
+			t.Errorf("expected %s/%d but got %s/%d", e.key, e.kind, diffs[i].Key, diffs[i].Kind)
+		}
+	}
+	if c := diffs[1].Changed; len(c) != 1 || c[0] != "Price" {
+		t.Errorf("expected Price to be changed but got %v", c)
+	}
+	if _, err := DiffRows(old, new, "Missing"); err == nil {
+		t.Error("expected an error for an unknown key column")
+	}
+}
+
+func TestDiffDuplicateKeys(t *testing.T) {
+	old, new := diffTables()
+	new.CreateRow().AddDefaultText("a").AddFloat(9, 0)
+	if _, err := Diff(old, new, "Name"); err == nil || !strings.Contains(err.Error(), `"a"`) {
+		t.Errorf("expected an error for the duplicate key but got %v", err)
+	}
+	old, new = diffTables()
+	old.CreateRow().AddDefaultText("b").AddFloat(9, 0)
+	if _, err := UnifiedDiff(old, new, "Name"); err == nil {
+		t.Error("expected an error for the duplicate key in the old table")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old, new := diffTables()
+	d, err := Diff(old, new, "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		name, price string
+		marker      int
+	}{
+		{"a", "1.00", 0},
+		{"c", "4.00 (3.00)", MarkerChanged},
+		{"d", "5.00", MarkerAdded},
+		{"b", "2.00", MarkerRemoved},
+	}
+	if d.Len() != len(expected) {
+		t.Fatalf("expected %d rows but got %d", len(expected), d.Len())
+	}
+	for i, e := range expected {
+		c := d.Rows[i].Cells
+		if c[0].Text != e.name || c[1].Text != e.price || c[1].Marker != e.marker {
+			t.Errorf("row %d: unexpected cells %q %q %d", i, c[0].Text, c[1].Text, c[1].Marker)
+		}
+	}
+	if mk := d.Rows[1].Cells[0].Marker; mk != 0 {
+		t.Errorf("expected the unchanged cell of a changed row to keep its marker but got %d", mk)
+	}
+	d, err = DiffWith(old, new, "Name", DiffOptions{Delta: true, HideUnchanged: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.TableHeaders) != 3 || d.TableHeaders[2].Text != "Δ Price" {
+		t.Fatalf("expected a delta column but got %v", d.TableHeaders)
+	}
+	if d.Len() != 3 {
+		t.Errorf("expected the unchanged row to be hidden but got %d rows", d.Len())
+	}
+	if c := d.Rows[0].Cells; c[1].Text != "4.00" || c[2].Text != "+1.00" || c[2].Marker != MarkerPositive {
+		t.Errorf("unexpected delta cells %q %q", c[1].Text, c[2].Text)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	old, new := diffTables()
+	old.Description = "monday"
+	txt, err := UnifiedDiff(old, new, "Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "--- monday\n" +
+		"+++ new\n" +
+		"@@ Name | Price @@\n" +
+		"- c | 3.00\n" +
+		"+ c | 4.00\n" +
+		"+ d | 5.00\n" +
+		"- b | 2.00\n"
+	if txt != expected {
+		t.Errorf("expected\n%s\nbut got\n%s", expected, txt)
+	}
+}
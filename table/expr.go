@@ -0,0 +1,565 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Expression is a parsed arithmetic expression which is evaluated per row.
+// Columns are referenced by name. Names containing spaces or operators
+// are written in brackets like [Close Price]. Supported functions are
+// abs, round, sqrt, min, max, pct and prev.
+type Expression struct {
+	Source string
+	root   exprNode
+}
+
+type exprContext struct {
+	table   *Table
+	row     int
+	columns map[string]int
+}
+
+type exprNode interface {
+	eval(ctx exprContext) float64
+}
+
+type exprNumber float64
+
+type exprColumn string
+
+type exprUnary struct {
+	arg exprNode
+}
+
+type exprBinary struct {
+	op          byte
+	left, right exprNode
+}
+
+type exprCall struct {
+	name string
+	args []exprNode
+}
+
+func (n exprNumber) eval(ctx exprContext) float64 {
+	return float64(n)
+}
+
+func (n exprColumn) eval(ctx exprContext) float64 {
+	if ctx.row < 0 || ctx.row >= len(ctx.table.Rows) {
+		return math.NaN()
+	}
+	idx, ok := ctx.columns[string(n)]
+	if !ok {
+		idx = ctx.table.FindColumnIndex(string(n))
+	}
+	r := ctx.table.Rows[ctx.row]
+	if idx == -1 || idx >= len(r.Cells) {
+		return math.NaN()
+	}
+	return r.Cells[idx].Value
+}
+
+func (n exprUnary) eval(ctx exprContext) float64 {
+	return -n.arg.eval(ctx)
+}
+
+func (n exprBinary) eval(ctx exprContext) float64 {
+	l := n.left.eval(ctx)
+	r := n.right.eval(ctx)
+	switch n.op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	case '/':
+		if r == 0.0 {
+			return math.NaN()
+		}
+		return l / r
+	case '%':
+		if r == 0.0 {
+			return math.NaN()
+		}
+		return math.Mod(l, r)
+	}
+	return math.NaN()
+}
+
+func (n exprCall) eval(ctx exprContext) float64 {
+	if n.name == "prev" {
+		ctx.row--
+		return n.args[0].eval(ctx)
+	}
+	args := make([]float64, len(n.args))
+	for i, a := range n.args {
+		args[i] = a.eval(ctx)
+	}
+	switch n.name {
+	case "abs":
+		return math.Abs(args[0])
+	case "sqrt":
+		return math.Sqrt(args[0])
+	case "round":
+		if len(args) == 2 {
+			p := math.Pow(10, math.Round(args[1]))
+			return math.Round(args[0]*p) / p
+		}
+		return math.Round(args[0])
+	case "min":
+		ret := args[0]
+		for _, v := range args[1:] {
+			ret = math.Min(ret, v)
+		}
+		return ret
+	case "max":
+		ret := args[0]
+		for _, v := range args[1:] {
+			ret = math.Max(ret, v)
+		}
+		return ret
+	case "pct":
+		base := 0.0
+		if len(args) == 2 {
+			base = args[1]
+		} else {
+			ctx.row--
+			base = n.args[0].eval(ctx)
+		}
+		if base == 0.0 {
+			return math.NaN()
+		}
+		return (args[0]/base - 1.0) * 100.0
+	}
+	return math.NaN()
+}
+
+// exprFunctions maps the function names to the allowed number of arguments
+var exprFunctions = map[string][2]int{
+	"abs":   {1, 1},
+	"sqrt":  {1, 1},
+	"round": {1, 2},
+	"min":   {2, -1},
+	"max":   {2, -1},
+	"pct":   {1, 2},
+	"prev":  {1, 1},
+}
+
+// ParseExpression parses the expression. Column names are not resolved
+// until the expression is evaluated.
+func ParseExpression(src string) (*Expression, error) {
+	p := exprParser{src: src}
+	p.next()
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.text, p.start)
+	}
+	return &Expression{Source: src, root: root}, nil
+}
+
+// Eval evaluates the expression for the given row. Missing values like
+// prev() in the first row or a division by zero return NaN.
+func (e *Expression) Eval(rt *Table, row int) float64 {
+	ctx := e.context(rt)
+	ctx.row = row
+	return e.root.eval(ctx)
+}
+
+// context resolves the indexes of the referenced columns once
+func (e *Expression) context(rt *Table) exprContext {
+	columns := make(map[string]int)
+	for _, c := range e.Columns() {
+		columns[c] = rt.FindColumnIndex(c)
+	}
+	return exprContext{table: rt, columns: columns}
+}
+
+// values evaluates the expression for all rows
+func (e *Expression) values(rt *Table) []float64 {
+	ctx := e.context(rt)
+	ret := make([]float64, len(rt.Rows))
+	for i := range rt.Rows {
+		ctx.row = i
+		ret[i] = e.root.eval(ctx)
+	}
+	return ret
+}
+
+// Columns returns the names of all referenced columns
+func (e *Expression) Columns() []string {
+	ret := make([]string, 0)
+	var walk func(n exprNode)
+	walk = func(n exprNode) {
+		switch v := n.(type) {
+		case exprColumn:
+			ret = append(ret, string(v))
+		case exprUnary:
+			walk(v.arg)
+		case exprBinary:
+			walk(v.left)
+			walk(v.right)
+		case exprCall:
+			for _, a := range v.args {
+				walk(a)
+			}
+		}
+	}
+	walk(e.root)
+	return ret
+}
+
+const (
+	tokEOF = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type exprParser struct {
+	src   string
+	pos   int
+	start int
+	tok   int
+	text  string
+	err   error
+}
+
+func (p *exprParser) next() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+	p.start = p.pos
+	if p.pos >= len(p.src) {
+		p.tok = tokEOF
+		p.text = ""
+		return
+	}
+	c, size := utf8.DecodeRuneInString(p.src[p.pos:])
+	switch {
+	case c == '[':
+		end := strings.IndexByte(p.src[p.pos:], ']')
+		if end == -1 {
+			p.err = fmt.Errorf("missing ] for column at position %d", p.pos)
+			p.tok = tokEOF
+			return
+		}
+		p.tok = tokIdent
+		p.text = p.src[p.pos+1 : p.pos+end]
+		p.pos += end + 1
+	case unicode.IsDigit(c) || c == '.':
+		for p.pos < len(p.src) && (unicode.IsDigit(rune(p.src[p.pos])) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		p.tok = tokNumber
+		p.text = p.src[p.start:p.pos]
+	case unicode.IsLetter(c) || c == '_':
+		for p.pos < len(p.src) {
+			r, n := utf8.DecodeRuneInString(p.src[p.pos:])
+			if !isIdentChar(r) {
+				break
+			}
+			p.pos += n
+		}
+		p.tok = tokIdent
+		p.text = p.src[p.start:p.pos]
+	default:
+		p.pos += size
+		p.tok = tokOp
+		p.text = string(c)
+	}
+}
+
+func isIdentChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_'
+}
+
+func (p *exprParser) parseSum() (exprNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok == tokOp && (p.text == "+" || p.text == "-") {
+		op := p.text[0]
+		p.next()
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = exprBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseProduct() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok == tokOp && (p.text == "*" || p.text == "/" || p.text == "%") {
+		op := p.text[0]
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = exprBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.tok == tokOp && p.text == "-" {
+		p.next()
+		arg, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return exprUnary{arg: arg}, nil
+	}
+	if p.tok == tokOp && p.text == "+" {
+		p.next()
+		return p.parseUnary()
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	switch p.tok {
+	case tokNumber:
+		v, err := strconv.ParseFloat(p.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", p.text, p.start)
+		}
+		p.next()
+		return exprNumber(v), nil
+	case tokIdent:
+		name := p.text
+		bracketed := p.src[p.start] == '['
+		p.next()
+		if bracketed || p.tok != tokOp || p.text != "(" {
+			return exprColumn(name), nil
+		}
+		return p.parseCall(name)
+	case tokOp:
+		if p.text == "(" {
+			p.next()
+			n, err := p.parseSum()
+			if err != nil {
+				return nil, err
+			}
+			if p.tok != tokOp || p.text != ")" {
+				return nil, fmt.Errorf("missing ) at position %d", p.start)
+			}
+			p.next()
+			return n, nil
+		}
+		return nil, fmt.Errorf("unexpected %q at position %d", p.text, p.start)
+	}
+	return nil, fmt.Errorf("unexpected end of expression")
+}
+
+func (p *exprParser) parseCall(name string) (exprNode, error) {
+	arity, ok := exprFunctions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q", name)
+	}
+	start := p.start
+	p.next()
+	args := make([]exprNode, 0)
+	for !(p.tok == tokOp && p.text == ")") {
+		if len(args) > 0 {
+			if p.tok != tokOp || p.text != "," {
+				return nil, fmt.Errorf("expected , or ) at position %d", p.start)
+			}
+			p.next()
+		}
+		arg, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+	if len(args) < arity[0] || (arity[1] != -1 && len(args) > arity[1]) {
+		return nil, fmt.Errorf("wrong number of arguments for %s at position %d", name, start)
+	}
+	return exprCall{name: name, args: args}, nil
+}
+
+// AddComputedColumn adds a column whose values are calculated from the
+// other columns of the same row. All referenced columns must exist.
+// The values are formatted using fn.
+func (rt *Table) AddComputedColumn(name, expr string, fn FormatterFn) error {
+	e, err := ParseExpression(expr)
+	if err != nil {
+		return fmt.Errorf("column %q: %v", name, err)
+	}
+	for _, c := range e.Columns() {
+		if rt.FindColumnIndex(c) == -1 {
+			return fmt.Errorf("column %q: unknown column %q", name, c)
+		}
+	}
+	rt.TableHeaders = append(rt.TableHeaders, TableHeader{Text: name, Expr: e, Format: fn, Align: AlignRight})
+	rt.Recompute()
+	return nil
+}
+
+func (rt *Table) computed() bool {
+	for _, h := range rt.TableHeaders {
+		if h.Expr != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// computeOrder returns the indexes of the computed columns so that every
+// column comes after the computed columns it references. Cycles are
+// broken in the order of the columns.
+func (rt *Table) computeOrder() []int {
+	ret := make([]int, 0)
+	state := make(map[int]int)
+	var visit func(idx int)
+	visit = func(idx int) {
+		if state[idx] != 0 {
+			return
+		}
+		state[idx] = 1
+		for _, c := range rt.TableHeaders[idx].Expr.Columns() {
+			dep := rt.FindColumnIndex(c)
+			if dep != -1 && rt.TableHeaders[dep].Expr != nil {
+				visit(dep)
+			}
+		}
+		state[idx] = 2
+		ret = append(ret, idx)
+	}
+	for idx, h := range rt.TableHeaders {
+		if h.Expr != nil {
+			visit(idx)
+		}
+	}
+	return ret
+}
+
+// Recompute evaluates all computed columns again. It is called after
+// sorting and filtering and before the table is rendered so rows added
+// later are computed as well. Computed columns referencing other
+// computed columns are evaluated after them.
+func (rt *Table) Recompute() *Table {
+	if !rt.computed() {
+		return rt
+	}
+	for _, idx := range rt.computeOrder() {
+		h := rt.TableHeaders[idx]
+		values := h.Expr.values(rt)
+		fn := h.Format
+		if fn == nil {
+			fn = rt.Formatters.Float
+		}
+		for i, r := range rt.Rows {
+			for len(r.Cells) <= idx {
+				r.AddEmpty()
+			}
+			c := Cell{Text: h.Null, Value: values[i], Alignment: h.Align}
+			if !math.IsNaN(values[i]) {
+				txt, mk, al := fn(values, i)
+				c = Cell{Text: txt, Value: values[i], Marker: mk, Alignment: TextAlign(al)}
+			}
+			r.Cells[idx] = c
+		}
+	}
+	return rt
+}
+
+// rename returns a copy of the expression where the column old is replaced by name
+func (e *Expression) rename(old, name string) *Expression {
+	var walk func(n exprNode) exprNode
+	walk = func(n exprNode) exprNode {
+		switch v := n.(type) {
+		case exprColumn:
+			if string(v) == old {
+				return exprColumn(name)
+			}
+		case exprUnary:
+			return exprUnary{arg: walk(v.arg)}
+		case exprBinary:
+			return exprBinary{op: v.op, left: walk(v.left), right: walk(v.right)}
+		case exprCall:
+			args := make([]exprNode, len(v.args))
+			for i, a := range v.args {
+				args[i] = walk(a)
+			}
+			return exprCall{name: v.name, args: args}
+		}
+		return n
+	}
+	root := walk(e.root)
+	return &Expression{Source: exprString(root), root: root}
+}
+
+func precedence(n exprNode) int {
+	if b, ok := n.(exprBinary); ok {
+		if b.op == '+' || b.op == '-' {
+			return 1
+		}
+		return 2
+	}
+	return 3
+}
+
+// exprString converts the node back into the expression syntax
+func exprString(n exprNode) string {
+	switch v := n.(type) {
+	case exprNumber:
+		return strconv.FormatFloat(float64(v), 'f', -1, 64)
+	case exprColumn:
+		for _, c := range string(v) {
+			if !isIdentChar(c) {
+				return "[" + string(v) + "]"
+			}
+		}
+		if _, ok := exprFunctions[string(v)]; ok || string(v) == "" {
+			return "[" + string(v) + "]"
+		}
+		return string(v)
+	case exprUnary:
+		if precedence(v.arg) < 3 {
+			return "-(" + exprString(v.arg) + ")"
+		}
+		return "-" + exprString(v.arg)
+	case exprBinary:
+		l := exprString(v.left)
+		if precedence(v.left) < precedence(v) {
+			l = "(" + l + ")"
+		}
+		r := exprString(v.right)
+		if precedence(v.right) <= precedence(v) && !(precedence(v.right) == precedence(v) && (v.op == '+' || v.op == '*')) {
+			r = "(" + r + ")"
+		}
+		return l + " " + string(v.op) + " " + r
+	case exprCall:
+		args := make([]string, len(v.args))
+		for i, a := range v.args {
+			args[i] = exprString(a)
+		}
+		return v.name + "(" + strings.Join(args, ", ") + ")"
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package table
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestComputedColumnAfterAddingRows(t *testing.T) {
+	tbl := New().Headers("High", "Low")
+	tbl.CreateRow().AddFloat(12, 0).AddFloat(10, 0)
+	if err := tbl.AddComputedColumn("Spread", "High - Low", nil); err != nil {
+		t.Fatal(err)
+	}
+	tbl.CreateRow().AddFloat(20, 0).AddFloat(15, 0)
+	out := tbl.Plain()
+	if !strings.Contains(out, "2.00") || !strings.Contains(out, "5.00") {
+		t.Errorf("expected both spreads in\n%s", out)
+	}
+	if v := tbl.Rows[1].Cells[2].Value; v != 5.0 {
+		t.Errorf("expected 5 but got %v", v)
+	}
+}
+
+func TestExpressionEval(t *testing.T) {
+	tbl := New().Headers("Close", "Open")
+	tbl.CreateRow().AddFloat(10, 0).AddFloat(8, 0)
+	tbl.CreateRow().AddFloat(12, 0).AddFloat(0, 0)
+	tests := []struct {
+		expr     string
+		row      int
+		expected float64
+	}{
+		{"Close - Open", 0, 2},
+		{"pct(Close)", 1, 20},
+		{"prev(Close) * 2", 1, 20},
+		{"round(Close / 3, 2)", 0, 3.33},
+		{"max(Close, Open, 11)", 0, 11},
+	}
+	for _, tc := range tests {
+		e, err := ParseExpression(tc.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.expr, err)
+		}
+		if v := e.Eval(tbl, tc.row); math.Abs(v-tc.expected) > 1e-9 {
+			t.Errorf("%s: expected %v but got %v", tc.expr, tc.expected, v)
+		}
+	}
+	for _, src := range []string{"Close / Open", "prev(Close)", "Unknown"} {
+		e, _ := ParseExpression(src)
+		row := 1
+		if src == "prev(Close)" {
+			row = 0
+		}
+		if v := e.Eval(tbl, row); !math.IsNaN(v) {
+			t.Errorf("%s: expected NaN but got %v", src, v)
+		}
+	}
+}
+
+func TestExpressionNonASCIIColumns(t *testing.T) {
+	tbl := New().Headers("Größe", "Menge", "Прибыль")
+	tbl.CreateRow().AddFloat(1.5, 0).AddFloat(4, 0).AddFloat(10, 0)
+	tests := []struct {
+		expr     string
+		expected float64
+	}{
+		{"Größe * Menge", 6},
+		{"Größe*Menge", 6},
+		{"Прибыль - Menge", 6},
+		{"max(Größe, [Menge])", 4},
+	}
+	for _, tc := range tests {
+		e, err := ParseExpression(tc.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.expr, err)
+		}
+		if v := e.Eval(tbl, 0); v != tc.expected {
+			t.Errorf("%s: expected %v but got %v", tc.expr, tc.expected, v)
+		}
+	}
+	if _, err := ParseExpression("Größe € 2"); err == nil || !strings.Contains(err.Error(), `"€"`) {
+		t.Errorf("expected an error for the unknown operator but got %v", err)
+	}
+}
+
+func TestComputedColumnOrder(t *testing.T) {
+	tbl := New().Headers("Price")
+	tbl.CreateRow().AddFloat(2, 0)
+	if err := tbl.AddComputedColumn("Double", "Price * 2", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := tbl.AddComputedColumn("Next", "Double + 1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := tbl.MoveColumn("Next", 0); err != nil {
+		t.Fatal(err)
+	}
+	tbl.Rows[0].Cells[tbl.FindColumnIndex("Price")].Value = 10
+	tbl.Recompute()
+	if v := tbl.Rows[0].Cells[tbl.FindColumnIndex("Next")].Value; v != 21 {
+		t.Errorf("expected 21 but got %v", v)
+	}
+}
@@ -1,8 +1,8 @@
 package table
 
 import (
-	"fmt"
 	"math"
+	"time"
 )
 
 type FormatterFn func(values []float64, index int) (string, int, int)
@@ -22,21 +22,30 @@ type Formatters struct {
 	CategorizedPercentage FormatterFn
 	CategorizedNorm       FormatterFn
 	BuySell               FormatterFn
+	Bar                   FormatterFn
+	Compact               FormatterFn
+	Bytes                 FormatterFn
+	Duration              FormatterFn
 }
 
 func DefaultFormatters() Formatters {
+	return FormattersWith(DefaultNumberFormat)
+}
+
+// FormattersWith creates the default formatters using the number format
+func FormattersWith(nf NumberFormat) Formatters {
 	return Formatters{
-		Percentage: func(values []float64, index int) (string, int, int) {
-			marker := 4
-			vc := math.Round(values[index]*100) / 100
-			if vc < 0.0 {
-				marker = 2
-			}
-			if vc > 0.0 {
-				marker = 6
-			}
-			return fmt.Sprintf("%.2f%%", vc), marker, 1
+		Bar: BarFormatter(DefaultBarOptions),
+		Compact: func(values []float64, index int) (string, int, int) {
+			return CompactNumber(values[index], 1), 0, 1
+		},
+		Bytes: func(values []float64, index int) (string, int, int) {
+			return IECBytes(values[index]), 0, 1
+		},
+		Duration: func(values []float64, index int) (string, int, int) {
+			return HumanDuration(time.Duration(values[index] * float64(time.Second))), 0, 1
 		},
+		Percentage: PercentFormatter(nf),
 		Relation: func(values []float64, index int) (string, int, int) {
 			marker := 4
 			vc := math.Round(values[index]*100) / 100
@@ -46,71 +55,12 @@ func DefaultFormatters() Formatters {
 			if vc > 1.0 {
 				marker = 6
 			}
-			return fmt.Sprintf("%.2f", vc), marker, 1
-		},
-		Categorized: func(values []float64, index int) (string, int, int) {
-			v := values[index]
-			marker := 4
-			//vc := math.Round(values[index]*100) / 100
-			if v < 20.0 {
-				marker = 2
-			} else if v < 40.0 {
-				marker = 3
-			} else if v < 60.0 {
-				marker = 4
-			} else if v < 80.0 {
-				marker = 5
-			} else {
-				marker = 6
-			}
-			return fmt.Sprintf("%.2f", v), marker, 1
-		},
-		CategorizedPercentage: func(values []float64, index int) (string, int, int) {
-			v := values[index]
-			marker := 4
-			if v < 20.0 {
-				marker = 2
-			} else if v < 40.0 {
-				marker = 3
-			} else if v < 60.0 {
-				marker = 4
-			} else if v < 80.0 {
-				marker = 5
-			} else {
-				marker = 6
-			}
-			return fmt.Sprintf("%.2f%%", v), marker, 1
-		},
-		CategorizedNorm: func(values []float64, index int) (string, int, int) {
-			v := values[index]
-			marker := 4
-			if v < 0.2 {
-				marker = 2
-			} else if v < 0.4 {
-				marker = 3
-			} else if v < 0.6 {
-				marker = 4
-			} else if v < 0.8 {
-				marker = 5
-			} else {
-				marker = 6
-			}
-			return fmt.Sprintf("%.2f", v), marker, 1
-		},
-		BuySell: func(values []float64, index int) (string, int, int) {
-			v := values[index]
-			txt := ""
-			marker := 4
-			if v == 1.0 {
-				txt = "BUY"
-				marker = 6
-			}
-			if v == -1.0 {
-				txt = "SELL"
-				marker = 2
-			}
-			return txt, marker, 1
+			return nf.Format(vc), marker, 1
 		},
+		Categorized:           CategorizedFormatter([]float64{20.0, 40.0, 60.0, 80.0}, nf.Format),
+		CategorizedPercentage: CategorizedFormatter([]float64{20.0, 40.0, 60.0, 80.0}, nf.Percent),
+		CategorizedNorm:       CategorizedFormatter([]float64{0.2, 0.4, 0.6, 0.8}, nf.Format),
+		BuySell:               BuySellFormatter(1.0, -1.0, "BUY", "SELL"),
 		Block: func(values []float64, index int) (string, int, int) {
 			marker := 4
 			if values[index] < 0.0 {
@@ -166,11 +116,11 @@ func DefaultFormatters() Formatters {
 		},
 		Float: func(values []float64, index int) (string, int, int) {
 			v := values[index]
-			return fmt.Sprintf("%.2f", v), 0, 1
+			return nf.Format(v), 0, 1
 		},
 		Int: func(values []float64, index int) (string, int, int) {
 			v := values[index]
-			return fmt.Sprintf("%d", int(v)), 0, 1
+			return nf.Int(v), 0, 1
 		},
 		MarkedFloat: func(values []float64, index int) (string, int, int) {
 			v := values[index]
@@ -181,7 +131,7 @@ func DefaultFormatters() Formatters {
 			if v > 0.0 {
 				mk = 6
 			}
-			return fmt.Sprintf("%.2f", v), mk, 1
+			return nf.Format(v), mk, 1
 		},
 		Histo: func(values []float64, index int) (string, int, int) {
 			c := values[index]
@@ -210,7 +160,7 @@ func DefaultFormatters() Formatters {
 				}
 			}
 			v := values[index]
-			return fmt.Sprintf("%.2f", v), mk, 1
+			return nf.Format(v), mk, 1
 		},
 		HistoInt: func(values []float64, index int) (string, int, int) {
 			c := values[index]
@@ -239,7 +189,56 @@ func DefaultFormatters() Formatters {
 				}
 			}
 			v := values[index]
-			return fmt.Sprintf("%d", int(v)), mk, 1
+			return nf.Int(v), mk, 1
 		},
 	}
 }
+
+// PercentFormatter marks negative values as class A and positive values as class E
+func PercentFormatter(nf NumberFormat) FormatterFn {
+	return func(values []float64, index int) (string, int, int) {
+		marker := 4
+		vc := math.Round(values[index]*100) / 100
+		if vc < 0.0 {
+			marker = 2
+		}
+		if vc > 0.0 {
+			marker = 6
+		}
+		return nf.Percent(vc), marker, 1
+	}
+}
+
+// CategorizedFormatter assigns the class markers A - F depending on how
+// many thresholds are less or equal to the value. The thresholds must be
+// in ascending order.
+func CategorizedFormatter(thresholds []float64, format func(v float64) string) FormatterFn {
+	return func(values []float64, index int) (string, int, int) {
+		v := values[index]
+		marker := MarkerClassA
+		for _, t := range thresholds {
+			if v >= t && marker < MarkerClassF {
+				marker++
+			}
+		}
+		return format(v), marker, 1
+	}
+}
+
+// BuySellFormatter shows the labels for the buy and sell values
+func BuySellFormatter(buy, sell float64, buyLabel, sellLabel string) FormatterFn {
+	return func(values []float64, index int) (string, int, int) {
+		v := values[index]
+		txt := ""
+		marker := 4
+		if v == buy {
+			txt = buyLabel
+			marker = 6
+		}
+		if v == sell {
+			txt = sellLabel
+			marker = 2
+		}
+		return txt, marker, 1
+	}
+}
@@ -11,7 +11,11 @@ const TableTemplate = `
         <thead>
           <tr>
             {{ range .TableHeaders }}
+            {{if eq .Marker 0 }}
             <th scope="col" style='text-align:center'>{{.Text}}</th>
+            {{else}}
+            <th scope="col" class='{{markerClass .Marker}}' style='text-align:center'>{{.Text}}</th>
+            {{end}}
             {{ end }}
           </tr>
         </thead>
@@ -20,26 +24,8 @@ const TableTemplate = `
             <tr>
               {{range .Cells}}
 				{{ $clr := ""}}
-				{{if eq .Marker -1 }}
-					{{$clr = "background-color:#ff2222;"}}
-				{{end}}
-				{{if eq .Marker 2 }}
-					{{$clr = "background-color:#ff2222;"}}
-				{{end}}
-				{{if eq .Marker 3 }}
-					{{$clr = "background-color:#c0a102;"}}
-				{{end}}
-				{{if eq .Marker 4 }}
-					{{$clr = "background-color:#1a7091;"}}
-				{{end}}
-				{{if eq .Marker 5 }}
-					{{$clr = "background-color:#21870a;"}}
-				{{end}}
-				{{if eq .Marker 6 }}
-					{{$clr = "background-color:#00ff00;"}}
-				{{end}}
-				{{if eq .Marker 1 }}
-					{{$clr = "background-color:#00ff00;"}}
+				{{with markerColor .Marker }}
+					{{$clr = printf "background-color:%s;" .}}
 				{{end}}
 
 				{{$al := ""}}				
@@ -57,7 +43,7 @@ const TableTemplate = `
 					{{if eq .Marker 0 }}
                 		<td style='{{$al}}'>{{.Text}}</td>
 					{{else}}
-						<td style='{{$al}};{{$clr}}'>{{.Text}}</td>
+						<td class='{{markerClass .Marker}}' style='{{$al}};{{$clr}}'>{{.Text}}</td>
 					{{end}}
 				{{end}}
               {{end}}
@@ -74,26 +60,8 @@ const HeadlessTableTemplate = `
             <tr>
               {{range .Cells}}
 				{{ $clr := ""}}
-				{{if eq .Marker -1 }}
-					{{$clr = "color:#ff2222;"}}
-				{{end}}
-				{{if eq .Marker 2 }}
-					{{$clr = "color:#ff2222;"}}
-				{{end}}
-				{{if eq .Marker 3 }}
-					{{$clr = "color:#c0a102;"}}
-				{{end}}
-				{{if eq .Marker 4 }}
-					{{$clr = "color:#1a7091;"}}
-				{{end}}
-				{{if eq .Marker 5 }}
-					{{$clr = "color:#166a03;"}}
-				{{end}}
-				{{if eq .Marker 6 }}
-					{{$clr = "color:#6cc717;"}}
-				{{end}}
-				{{if eq .Marker 1 }}
-					{{$clr = "color:#6cc717;"}}
+				{{with markerTextColor .Marker }}
+					{{$clr = printf "color:%s;" .}}
 				{{end}}
 
 				{{$al := ""}}				
@@ -111,7 +79,7 @@ const HeadlessTableTemplate = `
 					{{if eq .Marker 0 }}
                 		<td style='{{$al}}'>{{.Text}}</td>
 					{{else}}
-						<td style='{{$al}};{{$clr}}'>{{.Text}}</td>
+						<td class='{{markerClass .Marker}}' style='{{$al}};{{$clr}}'>{{.Text}}</td>
 					{{end}}
 				{{end}}
               {{end}}
@@ -197,14 +165,45 @@ const InlineHeatMapTemplate = `
 {{end}}
 `
 
+func (rt *Table) htmlFuncs() template.FuncMap {
+	return template.FuncMap{
+		"markerColor": func(mk int) string {
+			return rt.cr.Markers.Get(mk).HtmlColor
+		},
+		"markerTextColor": func(mk int) string {
+			def := rt.cr.Markers.Get(mk)
+			if def.HtmlTextColor != "" {
+				return def.HtmlTextColor
+			}
+			return def.HtmlColor
+		},
+		"markerClass": func(mk int) string {
+			return rt.cr.Markers.Get(mk).HtmlClass
+		},
+	}
+}
+
+func (rt *Table) htmlData() interface{} {
+	headers, rows := visible(rt.TableHeaders, rt.view(false))
+	return struct {
+		Description  string
+		TableHeaders []TableHeader
+		Rows         []*Row
+	}{
+		Description:  rt.Description,
+		TableHeaders: headers,
+		Rows:         rows,
+	}
+}
+
 func (rt *Table) BuildHtml() string {
-	reportTemplate, err := template.New("report").Parse(TableTemplate)
+	reportTemplate, err := template.New("report").Funcs(rt.htmlFuncs()).Parse(TableTemplate)
 	if err != nil {
 		fmt.Println(err)
 		return ""
 	} else {
 		var doc bytes.Buffer
-		err := reportTemplate.Execute(&doc, rt)
+		err := reportTemplate.Execute(&doc, rt.htmlData())
 		if err != nil {
 			fmt.Println(err)
 			return ""
@@ -216,13 +215,13 @@ func (rt *Table) BuildHtml() string {
 }
 
 func (rt *Table) BuildHeadlessHtml() string {
-	reportTemplate, err := template.New("report").Parse(HeadlessTableTemplate)
+	reportTemplate, err := template.New("report").Funcs(rt.htmlFuncs()).Parse(HeadlessTableTemplate)
 	if err != nil {
 		fmt.Println(err)
 		return ""
 	} else {
 		var doc bytes.Buffer
-		err := reportTemplate.Execute(&doc, rt)
+		err := reportTemplate.Execute(&doc, rt.htmlData())
 		if err != nil {
 			fmt.Println(err)
 			return ""
@@ -232,13 +231,13 @@ func (rt *Table) BuildHeadlessHtml() string {
 }
 
 func (rt *Table) BuildPlainHtml() string {
-	reportTemplate, err := template.New("report").Parse(TableTemplate)
+	reportTemplate, err := template.New("report").Funcs(rt.htmlFuncs()).Parse(TableTemplate)
 	if err != nil {
 		fmt.Println(err)
 		return ""
 	} else {
 		var doc bytes.Buffer
-		err := reportTemplate.Execute(&doc, rt)
+		err := reportTemplate.Execute(&doc, rt.htmlData())
 		if err != nil {
 			fmt.Println(err)
 			return ""
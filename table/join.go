@@ -0,0 +1,167 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+)
+
+type JoinKind int
+
+const (
+	// JoinInner keeps only rows with a key in both tables
+	JoinInner JoinKind = iota
+	// JoinLeft keeps all rows of the left table
+	JoinLeft
+	// JoinOuter keeps all rows of both tables
+	JoinOuter
+)
+
+func joinPrefix(rt *Table, fallback string) string {
+	if rt.Description != "" {
+		return rt.Description
+	}
+	return fallback
+}
+
+// Join combines the rows of both tables with the same text in the column on.
+// The result contains the columns of the left table followed by the columns
+// of the right table except the key column. Column names which exist in both
+// tables are prefixed with the name of the table like "prices.Close".
+// An error is returned if the prefixed names are not unique, for example
+// if both tables have the same description.
+func Join(left, right *Table, on string, kind JoinKind) (*Table, error) {
+	li := left.FindColumnIndex(on)
+	if li == -1 {
+		return nil, fmt.Errorf("unknown column %q in left table", on)
+	}
+	ri := right.FindColumnIndex(on)
+	if ri == -1 {
+		return nil, fmt.Errorf("unknown column %q in right table", on)
+	}
+	ret := left.derive()
+	ret.TableHeaders = make([]TableHeader, 0, len(left.TableHeaders)+len(right.TableHeaders)-1)
+	lp := joinPrefix(left, "left")
+	rp := joinPrefix(right, "right")
+	for i, h := range left.TableHeaders {
+		if i != li && right.FindColumnIndex(h.Text) != -1 {
+			h.Text = lp + "." + h.Text
+		}
+		h.Expr = nil
+		ret.TableHeaders = append(ret.TableHeaders, h)
+	}
+	for i, h := range right.TableHeaders {
+		if i == ri {
+			continue
+		}
+		if left.FindColumnIndex(h.Text) != -1 {
+			h.Text = rp + "." + h.Text
+		}
+		h.Expr = nil
+		ret.TableHeaders = append(ret.TableHeaders, h)
+	}
+	seen := make(map[string]bool)
+	for _, h := range ret.TableHeaders {
+		if h.Text != "" && seen[h.Text] {
+			return nil, fmt.Errorf("duplicate column %q in joined table, use different descriptions for the tables", h.Text)
+		}
+		seen[h.Text] = true
+	}
+	joinKey := func(r *Row, idx int) string {
+		if idx < len(r.Cells) {
+			return r.Cells[idx].Text
+		}
+		return ""
+	}
+	index := make(map[string][]*Row)
+	for _, r := range right.Rows {
+		k := joinKey(r, ri)
+		index[k] = append(index[k], r)
+	}
+	matched := make(map[*Row]bool)
+	for _, l := range left.Rows {
+		matches := index[joinKey(l, li)]
+		if len(matches) == 0 && kind != JoinInner {
+			ret.joinRow(l, nil, len(left.TableHeaders), ri)
+		}
+		for _, r := range matches {
+			ret.joinRow(l, r, len(left.TableHeaders), ri)
+			matched[r] = true
+		}
+	}
+	if kind == JoinOuter {
+		for _, r := range right.Rows {
+			if matched[r] {
+				continue
+			}
+			row := ret.joinRow(nil, r, len(left.TableHeaders), ri)
+			if ri < len(r.Cells) {
+				row.Cells[li] = r.Cells[ri]
+			}
+		}
+	}
+	return ret, nil
+}
+
+// joinRow appends a row with the cells of l and r. Missing rows or cells
+// are filled with empty cells.
+func (rt *Table) joinRow(l, r *Row, leftSize, skip int) *Row {
+	row := rt.CreateRow()
+	cells := make([]Cell, leftSize)
+	if l != nil {
+		copy(cells, l.Cells)
+		row.Key = l.Key
+		row.Highlighted = l.Highlighted
+	}
+	if r != nil {
+		for i, c := range r.Cells {
+			if i != skip {
+				cells = append(cells, c)
+			}
+		}
+		if row.Key == "" {
+			row.Key = r.Key
+		}
+	}
+	for len(cells) < len(rt.TableHeaders) {
+		cells = append(cells, Cell{})
+	}
+	row.Cells = cells
+	return row
+}
+
+func headerNames(headers []TableHeader) string {
+	names := make([]string, len(headers))
+	for i, h := range headers {
+		names[i] = h.Text
+	}
+	return strings.Join(names, ", ")
+}
+
+// Append adds copies of the rows of the other table. Both tables must
+// have the same column names in the same order.
+func (rt *Table) Append(other *Table) error {
+	if headerNames(rt.TableHeaders) != headerNames(other.TableHeaders) {
+		return fmt.Errorf("incompatible headers [%s] and [%s]", headerNames(rt.TableHeaders), headerNames(other.TableHeaders))
+	}
+	for _, r := range other.Rows {
+		rt.appendCopy(r)
+	}
+	rt.keys = nil
+	rt.Recompute()
+	return nil
+}
+
+// Concat returns a new table containing the rows of all tables. The
+// headers and styles are taken from the first table.
+func Concat(tables ...*Table) (*Table, error) {
+	if len(tables) == 0 {
+		return New(), nil
+	}
+	ret := tables[0].derive()
+	for _, t := range tables {
+		if err := ret.Append(t); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,40 @@
+package table
+
+import "testing"
+
+func joinTables(left, right string) (*Table, *Table) {
+	l := New().Headers("Symbol", "Close")
+	l.Description = left
+	l.CreateRow().AddDefaultText("A").AddFloat(1, 0)
+	r := New().Headers("Symbol", "Close")
+	r.Description = right
+	r.CreateRow().AddDefaultText("A").AddFloat(2, 0)
+	return l, r
+}
+
+func TestJoinPrefixesColumns(t *testing.T) {
+	l, r := joinTables("prices", "")
+	ret, err := Join(l, r, "Symbol", JoinInner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := ""
+	for _, h := range ret.TableHeaders {
+		got += h.Text + " "
+	}
+	if got != "Symbol prices.Close right.Close " {
+		t.Errorf("unexpected headers %s", got)
+	}
+}
+
+func TestJoinRejectsDuplicateNames(t *testing.T) {
+	l, r := joinTables("prices", "prices")
+	if _, err := Join(l, r, "Symbol", JoinInner); err == nil {
+		t.Error("expected an error for tables with the same description")
+	}
+	l, r = joinTables("", "")
+	l.AddTableHeader("right.Close")
+	if _, err := Join(l, r, "Symbol", JoinLeft); err == nil {
+		t.Error("expected an error for a prefixed name which already exists")
+	}
+}
@@ -0,0 +1,27 @@
+package table
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/amecky/table/term"
+)
+
+// Watch redraws the table returned by fn in place every interval until
+// the context is done or the program is interrupted. fn is called on the
+// goroutine of Watch so it can safely update and return the table.
+func Watch(ctx context.Context, w io.Writer, interval time.Duration, fn func() *Table) error {
+	return term.NewLive(w).Run(ctx, interval, func() string {
+		return fn().String()
+	})
+}
+
+// Watch redraws the table in place every interval. The table must not be
+// modified by other goroutines while it is watched, use Watch with a
+// function in that case.
+func (rt *Table) Watch(ctx context.Context, w io.Writer, interval time.Duration) error {
+	return Watch(ctx, w, interval, func() *Table {
+		return rt
+	})
+}
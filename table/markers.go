@@ -0,0 +1,155 @@
+package table
+
+import "github.com/amecky/table/term"
+
+const (
+	// MarkerNegative marks a negative value
+	MarkerNegative = -1
+	// MarkerNone uses the default text style
+	MarkerNone = 0
+	// MarkerPositive marks a positive value
+	MarkerPositive = 1
+	// MarkerClassA is the lowest category (red)
+	MarkerClassA = 2
+	// MarkerClassB category (orange)
+	MarkerClassB = 3
+	// MarkerClassC category (blue)
+	MarkerClassC = 4
+	// MarkerClassD category (green)
+	MarkerClassD = 5
+	// MarkerClassE category (light green)
+	MarkerClassE = 6
+	// MarkerClassF is the highest category
+	MarkerClassF = 7
+	// MarkerAdded marks a row which was added in a diff
+	MarkerAdded = 8
+	// MarkerRemoved marks a row which was removed in a diff
+	MarkerRemoved = 9
+	// MarkerChanged marks a changed cell in a diff
+	MarkerChanged = 10
+	// MarkerCustom is the first id handed out for user defined markers
+	MarkerCustom = 20
+)
+
+// MarkerDef describes how a marker is rendered by the different renderers
+type MarkerDef struct {
+	Name    string
+	Style   term.Style
+	Striped term.Style
+	Header  term.Style
+	// HtmlColor is the background color of cells in HTML tables
+	HtmlColor string
+	// HtmlTextColor is the text color used by the headless HTML table. The
+	// HtmlColor is used if it is empty.
+	HtmlTextColor string
+	HtmlClass     string
+	Symbol        string
+}
+
+// MarkerRegistry maps marker ids to their definitions
+type MarkerRegistry struct {
+	defs  map[int]MarkerDef
+	names map[string]int
+	next  int
+}
+
+func NewMarkerRegistry(styles Styles) *MarkerRegistry {
+	mr := &MarkerRegistry{
+		defs:  make(map[int]MarkerDef),
+		names: make(map[string]int),
+		next:  MarkerCustom,
+	}
+	mr.Set(MarkerNone, MarkerDef{Name: "none"})
+	mr.Set(MarkerNegative, MarkerDef{Name: "negative", HtmlColor: "#ff2222", HtmlClass: "mk-negative", Symbol: "-"})
+	mr.Set(MarkerPositive, MarkerDef{Name: "positive", HtmlColor: "#00ff00", HtmlTextColor: "#6cc717", HtmlClass: "mk-positive", Symbol: "+"})
+	mr.Set(MarkerClassA, MarkerDef{Name: "a", HtmlColor: "#ff2222", HtmlClass: "mk-class-a", Symbol: "A"})
+	mr.Set(MarkerClassB, MarkerDef{Name: "b", HtmlColor: "#c0a102", HtmlClass: "mk-class-b", Symbol: "B"})
+	mr.Set(MarkerClassC, MarkerDef{Name: "c", HtmlColor: "#1a7091", HtmlClass: "mk-class-c", Symbol: "C"})
+	mr.Set(MarkerClassD, MarkerDef{Name: "d", HtmlColor: "#21870a", HtmlTextColor: "#166a03", HtmlClass: "mk-class-d", Symbol: "D"})
+	mr.Set(MarkerClassE, MarkerDef{Name: "e", HtmlColor: "#00ff00", HtmlTextColor: "#6cc717", HtmlClass: "mk-class-e", Symbol: "E"})
+	mr.Set(MarkerClassF, MarkerDef{Name: "f", HtmlColor: "#209c05", HtmlClass: "mk-class-f", Symbol: "F"})
+	mr.Set(MarkerAdded, MarkerDef{
+		Name:      "added",
+		Style:     term.NewStyle(LIGHT_GREEN, "", true),
+		Striped:   term.NewStyle(LIGHT_GREEN, BG_COLOR_ODD, true),
+		Header:    term.NewStyle("#0C0C0C", LIGHT_GREEN, true),
+		HtmlColor: "#00ff00",
+		HtmlClass: "mk-added",
+		Symbol:    "+",
+	})
+	mr.Set(MarkerRemoved, MarkerDef{
+		Name:      "removed",
+		Style:     term.NewStyle(RED, "", false).Strikethrough(),
+		Striped:   term.NewStyle(RED, BG_COLOR_ODD, false).Strikethrough(),
+		Header:    term.NewStyle("#ffffff", RED, true),
+		HtmlColor: "#ff2222",
+		HtmlClass: "mk-removed",
+		Symbol:    "-",
+	})
+	mr.Set(MarkerChanged, MarkerDef{
+		Name:      "changed",
+		Style:     term.NewStyle(ORANGE, "", true),
+		Striped:   term.NewStyle(ORANGE, BG_COLOR_ODD, true),
+		Header:    term.NewStyle("#ffffff", ORANGE, true),
+		HtmlColor: "#c0a102",
+		HtmlClass: "mk-changed",
+		Symbol:    "*",
+	})
+	mr.ApplyStyles(styles)
+	return mr
+}
+
+// ApplyStyles updates the console styles of the builtin markers
+func (mr *MarkerRegistry) ApplyStyles(s Styles) {
+	mr.setStyles(MarkerNone, s.Text, s.TextStriped, s.Text)
+	mr.setStyles(MarkerNegative, s.NegativeMarker, s.NegativeMarkerStriped, s.HeaderNegative)
+	mr.setStyles(MarkerPositive, s.PositiveMarker, s.PositiveMarkerStriped, s.HeaderPositive)
+	mr.setStyles(MarkerClassA, s.ClassAMarker, s.ClassAMarkerStriped, s.HeaderClassA)
+	mr.setStyles(MarkerClassB, s.ClassBMarker, s.ClassBMarkerStriped, s.HeaderClassB)
+	mr.setStyles(MarkerClassC, s.ClassCMarker, s.ClassCMarkerStriped, s.HeaderClassC)
+	mr.setStyles(MarkerClassD, s.ClassDMarker, s.ClassDMarkerStriped, s.HeaderClassD)
+	mr.setStyles(MarkerClassE, s.ClassEMarker, s.ClassEMarkerStriped, s.HeaderClassE)
+	mr.setStyles(MarkerClassF, s.ClassFMarker, s.ClassFMarkerStriped, s.HeaderClassF)
+}
+
+func (mr *MarkerRegistry) setStyles(id int, st, striped, header term.Style) {
+	def := mr.defs[id]
+	def.Style = st
+	def.Striped = striped
+	def.Header = header
+	mr.defs[id] = def
+}
+
+// Set defines or replaces the marker with the given id
+func (mr *MarkerRegistry) Set(id int, def MarkerDef) {
+	mr.defs[id] = def
+	if def.Name != "" {
+		mr.names[def.Name] = id
+	}
+	if id >= mr.next {
+		mr.next = id + 1
+	}
+}
+
+// Register adds a new marker and returns its id
+func (mr *MarkerRegistry) Register(def MarkerDef) int {
+	id := mr.next
+	mr.Set(id, def)
+	return id
+}
+
+// Get returns the definition of the marker. Unknown markers fall back to MarkerNone
+func (mr *MarkerRegistry) Get(id int) MarkerDef {
+	if def, ok := mr.defs[id]; ok {
+		return def
+	}
+	return mr.defs[MarkerNone]
+}
+
+// Lookup returns the id of a named marker or MarkerNone
+func (mr *MarkerRegistry) Lookup(name string) int {
+	if id, ok := mr.names[name]; ok {
+		return id
+	}
+	return MarkerNone
+}
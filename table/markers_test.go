@@ -0,0 +1,74 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkerRegistry(t *testing.T) {
+	tbl := New()
+	mr := tbl.Markers()
+	if id := mr.Lookup("positive"); id != MarkerPositive {
+		t.Errorf("expected %d for positive but got %d", MarkerPositive, id)
+	}
+	if id := mr.Lookup("unknown"); id != MarkerNone {
+		t.Errorf("expected MarkerNone for an unknown name but got %d", id)
+	}
+	id := tbl.DefineMarker(MarkerDef{Name: "warning", HtmlColor: "#ffaa00", Symbol: "!"})
+	if id != MarkerCustom {
+		t.Errorf("expected the first custom marker to be %d but got %d", MarkerCustom, id)
+	}
+	if next := tbl.DefineMarker(MarkerDef{Name: "info"}); next != id+1 {
+		t.Errorf("expected %d but got %d", id+1, next)
+	}
+	if mr.Lookup("warning") != id || mr.Get(id).Symbol != "!" {
+		t.Error("expected to find the custom marker by name")
+	}
+	if def := mr.Get(99); def.Name != "none" {
+		t.Errorf("expected unknown ids to fall back to none but got %q", def.Name)
+	}
+}
+
+func TestPlainShowsMarkerSymbols(t *testing.T) {
+	tbl := New().Headers("Name", "Change")
+	warn := tbl.DefineMarker(MarkerDef{Name: "warning", Symbol: "!"})
+	tbl.CreateRow().AddDefaultText("a").AddText("1.5", MarkerPositive)
+	tbl.CreateRow().AddDefaultText("b").AddText("-2.0", MarkerNegative)
+	tbl.CreateRow().AddDefaultText("c").AddText("0.0", warn)
+	expected := strings.Join([]string{
+		"┌──────┬────────┐",
+		"│ Name │ Change │",
+		"├──────┼────────┤",
+		"│ a    │ 1.5 +  │",
+		"│ b    │ -2.0 - │",
+		"│ c    │ 0.0 !  │",
+		"└──────┴────────┘",
+	}, "\n")
+	if got := tbl.Plain(); got != expected {
+		t.Errorf("unexpected output\n%s\nexpected\n%s", got, expected)
+	}
+	if strings.ContainsRune(tbl.Plain(), 0x1b) {
+		t.Error("Plain must not contain escape sequences")
+	}
+}
+
+func TestHtmlMarkerColors(t *testing.T) {
+	tbl := New().Headers("Name", "Value")
+	tbl.CreateRow().AddText("a", MarkerPositive).AddText("b", MarkerClassD)
+	headless := tbl.BuildHeadlessHtml()
+	for _, c := range []string{"color:#6cc717;", "color:#166a03;"} {
+		if !strings.Contains(headless, c) {
+			t.Errorf("expected %s in the headless table", c)
+		}
+	}
+	html := tbl.BuildHtml()
+	for _, c := range []string{"background-color:#00ff00;", "background-color:#21870a;"} {
+		if !strings.Contains(html, c) {
+			t.Errorf("expected %s in the table", c)
+		}
+	}
+	tbl.SetHeaderMarker(1, MarkerPositive)
+	if strings.Contains(tbl.BuildHtml(), "text-align:center;background-color") {
+		t.Error("headers must not get a background color")
+	}
+}
@@ -0,0 +1,264 @@
+package table
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SignNegative shows a sign only for negative numbers
+	SignNegative = iota
+	// SignAlways shows a sign for negative and positive numbers
+	SignAlways
+	// SignNever never shows a sign
+	SignNever
+)
+
+// NumberFormat describes how numbers are converted to text. If Significant
+// is greater than zero it is used instead of Precision.
+type NumberFormat struct {
+	Precision      int
+	Significant    int
+	Thousands      string
+	Decimal        string
+	Currency       string
+	CurrencySuffix bool
+	Sign           int
+	Parentheses    bool
+}
+
+var DefaultNumberFormat = NumberFormat{
+	Precision: 2,
+	Decimal:   ".",
+}
+
+var locales = map[string]NumberFormat{
+	"en":    {Precision: 2, Thousands: ",", Decimal: "."},
+	"en-US": {Precision: 2, Thousands: ",", Decimal: ".", Currency: "$"},
+	"en-GB": {Precision: 2, Thousands: ",", Decimal: ".", Currency: "£"},
+	"de":    {Precision: 2, Thousands: ".", Decimal: ",", Currency: "€", CurrencySuffix: true},
+	"de-DE": {Precision: 2, Thousands: ".", Decimal: ",", Currency: "€", CurrencySuffix: true},
+	"de-AT": {Precision: 2, Thousands: ".", Decimal: ",", Currency: "€"},
+	"de-CH": {Precision: 2, Thousands: "'", Decimal: ".", Currency: "CHF"},
+	"fr":    {Precision: 2, Thousands: " ", Decimal: ",", Currency: "€", CurrencySuffix: true},
+	"fr-FR": {Precision: 2, Thousands: " ", Decimal: ",", Currency: "€", CurrencySuffix: true},
+	"it-IT": {Precision: 2, Thousands: ".", Decimal: ",", Currency: "€", CurrencySuffix: true},
+	"es-ES": {Precision: 2, Thousands: ".", Decimal: ",", Currency: "€", CurrencySuffix: true},
+	"ja-JP": {Precision: 0, Thousands: ",", Decimal: ".", Currency: "¥"},
+}
+
+func lookupLocale(locale string) NumberFormat {
+	locale = strings.Replace(locale, "_", "-", -1)
+	if nf, ok := locales[locale]; ok {
+		return nf
+	}
+	if idx := strings.Index(locale, "-"); idx != -1 {
+		if nf, ok := locales[locale[:idx]]; ok {
+			return nf
+		}
+	}
+	return DefaultNumberFormat
+}
+
+// LocaleNumberFormat returns the separators of the locale (like "de-DE").
+// Unknown locales fall back to the language and finally to the
+// DefaultNumberFormat.
+func LocaleNumberFormat(locale string) NumberFormat {
+	nf := lookupLocale(locale)
+	nf.Currency = ""
+	nf.CurrencySuffix = false
+	return nf
+}
+
+// LocaleCurrencyFormat returns the separators and the currency symbol
+// and placement of the locale
+func LocaleCurrencyFormat(locale string) NumberFormat {
+	return lookupLocale(locale)
+}
+
+func (nf NumberFormat) WithPrecision(precision int) NumberFormat {
+	nf.Precision = precision
+	nf.Significant = 0
+	return nf
+}
+
+func (nf NumberFormat) WithSignificant(digits int) NumberFormat {
+	nf.Significant = digits
+	return nf
+}
+
+func (nf NumberFormat) WithCurrency(symbol string, suffix bool) NumberFormat {
+	nf.Currency = symbol
+	nf.CurrencySuffix = suffix
+	return nf
+}
+
+func (nf NumberFormat) WithSign(sign int) NumberFormat {
+	nf.Sign = sign
+	return nf
+}
+
+// WithParentheses shows negative numbers in parentheses instead of a minus sign
+func (nf NumberFormat) WithParentheses() NumberFormat {
+	nf.Parentheses = true
+	return nf
+}
+
+func (nf NumberFormat) precision(v float64) int {
+	if nf.Significant <= 0 {
+		if nf.Precision < 0 {
+			return 0
+		}
+		return nf.Precision
+	}
+	if v == 0.0 {
+		return nf.Significant - 1
+	}
+	p := nf.Significant - 1 - int(math.Floor(math.Log10(v)))
+	if p < 0 {
+		return 0
+	}
+	return p
+}
+
+// roundTo rounds the value to the number of decimals
+func roundTo(v float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(v*scale) / scale
+}
+
+func groupDigits(digits, sep string) string {
+	if sep == "" || len(digits) <= 3 {
+		return digits
+	}
+	sb := strings.Builder{}
+	lead := len(digits) % 3
+	if lead > 0 {
+		sb.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+func (nf NumberFormat) number(v float64) (string, bool) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64), false
+	}
+	a := math.Abs(v)
+	p := nf.precision(a)
+	if nf.Significant > 0 && a != 0.0 {
+		// rounding may carry into the next digit like 9.996 to 10.00
+		if r := nf.precision(roundTo(a, p)); r < p {
+			p = r
+		}
+	}
+	if nf.Significant > 0 {
+		// significant digits may cut off integer digits as well
+		scale := math.Pow(10, float64(nf.Significant-1-int(math.Floor(math.Log10(a)))))
+		if a != 0.0 && scale < 1.0 {
+			a = math.Round(a*scale) / scale
+		}
+	}
+	txt := strconv.FormatFloat(a, 'f', p, 64)
+	frac := ""
+	if idx := strings.Index(txt, "."); idx != -1 {
+		frac = txt[idx+1:]
+		txt = txt[:idx]
+	}
+	ret := groupDigits(txt, nf.Thousands)
+	if frac != "" {
+		dec := nf.Decimal
+		if dec == "" {
+			dec = "."
+		}
+		ret += dec + frac
+	}
+	zero := strings.Trim(txt+frac, "0") == ""
+	return ret, v < 0.0 && !zero
+}
+
+func (nf NumberFormat) sign(txt string, v float64, negative bool) string {
+	if negative {
+		if nf.Sign == SignNever {
+			return txt
+		}
+		if nf.Parentheses {
+			return "(" + txt + ")"
+		}
+		return "-" + txt
+	}
+	if nf.Sign == SignAlways && v > 0.0 {
+		return "+" + txt
+	}
+	return txt
+}
+
+// Format converts the number to text including the currency symbol
+func (nf NumberFormat) Format(v float64) string {
+	txt, negative := nf.number(v)
+	if nf.Currency != "" {
+		if nf.CurrencySuffix {
+			txt = txt + " " + nf.Currency
+		} else {
+			sep := ""
+			if internalLen(nf.Currency) > 1 {
+				sep = " "
+			}
+			txt = nf.Currency + sep + txt
+		}
+	}
+	return nf.sign(txt, v, negative)
+}
+
+// Percent converts the number to text followed by a percent sign. The currency is ignored
+func (nf NumberFormat) Percent(v float64) string {
+	txt, negative := nf.number(v)
+	return nf.sign(txt+"%", v, negative)
+}
+
+// Int converts the number to text without any decimals
+func (nf NumberFormat) Int(v float64) string {
+	nf.Significant = 0
+	nf.Precision = 0
+	nf.Currency = ""
+	return nf.Format(math.Trunc(v))
+}
+
+// Numbers sets the default number format of the table. Only the default
+// formatters switch to the new format, formatters assigned by the caller
+// are kept.
+func (rt *Table) Numbers(nf NumberFormat) *Table {
+	rt.NumberFormat = nf
+	rt.Formatters = mergeFormatters(rt.Formatters, FormattersWith(nf))
+	return rt
+}
+
+// mergeFormatters replaces the formatters in current which are missing or
+// were created by the same code as the one in defaults. Go can not compare
+// closures, so a formatter built by the same constructor (like
+// PercentFormatter) counts as a default one.
+func mergeFormatters(current, defaults Formatters) Formatters {
+	cv := reflect.ValueOf(&current).Elem()
+	dv := reflect.ValueOf(defaults)
+	for i := 0; i < cv.NumField(); i++ {
+		f := cv.Field(i)
+		if f.IsNil() || f.Pointer() == dv.Field(i).Pointer() {
+			f.Set(dv.Field(i))
+		}
+	}
+	return current
+}
+
+func (tr *Row) numberFormat() NumberFormat {
+	if tr.table != nil {
+		return tr.table.NumberFormat
+	}
+	return DefaultNumberFormat
+}
@@ -0,0 +1,53 @@
+package table
+
+import "testing"
+
+func TestNumberFormat(t *testing.T) {
+	de := LocaleNumberFormat("de-DE")
+	tests := []struct {
+		name     string
+		nf       NumberFormat
+		value    float64
+		expected string
+	}{
+		{"default", DefaultNumberFormat, 1234.567, "1234.57"},
+		{"thousands", LocaleNumberFormat("en"), 1234567.891, "1,234,567.89"},
+		{"locale", de, -1234.5, "-1.234,50"},
+		{"currency suffix", LocaleCurrencyFormat("de-DE"), 12.5, "12,50 €"},
+		{"currency prefix", LocaleCurrencyFormat("en-US"), 12.5, "$12.50"},
+		{"negative zero", DefaultNumberFormat, -0.001, "0.00"},
+		{"sign", DefaultNumberFormat.WithSign(SignAlways), 3, "+3.00"},
+		{"parentheses", DefaultNumberFormat.WithParentheses(), -3, "(3.00)"},
+		{"significant", DefaultNumberFormat.WithSignificant(3), 0.012345, "0.0123"},
+		{"significant integer", DefaultNumberFormat.WithSignificant(3), 123456, "123000"},
+		{"significant carry", DefaultNumberFormat.WithSignificant(4), 9.9996, "10.00"},
+		{"significant carry decimals", DefaultNumberFormat.WithSignificant(3), 9.996, "10.0"},
+		{"significant carry small", DefaultNumberFormat.WithSignificant(3), 0.09996, "0.100"},
+		{"significant carry integer", DefaultNumberFormat.WithSignificant(3), 99.96, "100"},
+		{"significant no carry", DefaultNumberFormat.WithSignificant(3), 9.994, "9.99"},
+	}
+	for _, tc := range tests {
+		if got := tc.nf.Format(tc.value); got != tc.expected {
+			t.Errorf("%s: expected %q but got %q", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestNumbersKeepsFormatters(t *testing.T) {
+	custom := func(values []float64, index int) (string, int, int) {
+		return "custom", 0, 1
+	}
+	tbl := New()
+	tbl.Formatters.Float = custom
+	tbl.Formatters.Bytes = nil
+	tbl.Numbers(LocaleNumberFormat("de-DE"))
+	if txt, _, _ := tbl.Formatters.Float([]float64{1.5}, 0); txt != "custom" {
+		t.Errorf("expected the custom formatter but got %q", txt)
+	}
+	if txt, _, _ := tbl.Formatters.MarkedFloat([]float64{1234.5}, 0); txt != "1.234,50" {
+		t.Errorf("expected the default formatter to use the new format but got %q", txt)
+	}
+	if tbl.Formatters.Bytes == nil {
+		t.Error("expected the missing formatter to be set")
+	}
+}
@@ -0,0 +1,304 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FormatterParams are the named parameters of a formatter spec
+type FormatterParams map[string]string
+
+// FormatterBuilder creates a formatter from its parameters
+type FormatterBuilder func(p FormatterParams) (FormatterFn, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]FormatterBuilder)
+)
+
+// RegisterFormatter adds or replaces the formatter with the given name
+func RegisterFormatter(name string, builder FormatterBuilder) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = builder
+}
+
+// FormatterNames returns the sorted names of all registered formatters
+func FormatterNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	ret := make([]string, 0, len(registry))
+	for k := range registry {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// LookupFormatter builds a formatter from a spec like
+// "categorized(thresholds=[10,25,50,75])" or just "percent"
+func LookupFormatter(spec string) (FormatterFn, error) {
+	name, params, err := ParseFormatterSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	registryMu.RLock()
+	builder, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown formatter %q", name)
+	}
+	fn, err := builder(params)
+	if err != nil {
+		return nil, fmt.Errorf("formatter %q: %v", name, err)
+	}
+	return fn, nil
+}
+
+// splitTopLevel splits at the separator ignoring separators within
+// brackets or parentheses
+func splitTopLevel(txt string, sep rune) []string {
+	ret := make([]string, 0)
+	depth := 0
+	start := 0
+	for i, r := range txt {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+		}
+		if r == sep && depth == 0 {
+			ret = append(ret, txt[start:i])
+			start = i + 1
+		}
+	}
+	return append(ret, txt[start:])
+}
+
+// ParseFormatterSpec splits a spec into the name and its parameters
+func ParseFormatterSpec(spec string) (string, FormatterParams, error) {
+	spec = strings.TrimSpace(spec)
+	params := make(FormatterParams)
+	idx := strings.Index(spec, "(")
+	if idx == -1 {
+		return spec, params, nil
+	}
+	if !strings.HasSuffix(spec, ")") {
+		return "", nil, fmt.Errorf("missing closing parenthesis in %q", spec)
+	}
+	name := strings.TrimSpace(spec[:idx])
+	args := strings.TrimSpace(spec[idx+1 : len(spec)-1])
+	if args == "" {
+		return name, params, nil
+	}
+	for _, a := range splitTopLevel(args, ',') {
+		kv := strings.SplitN(a, "=", 2)
+		if len(kv) != 2 {
+			return "", nil, fmt.Errorf("invalid parameter %q in %q", a, spec)
+		}
+		params[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return name, params, nil
+}
+
+func unquote(txt string) string {
+	if len(txt) >= 2 && (txt[0] == '"' || txt[0] == '\'') && txt[len(txt)-1] == txt[0] {
+		return txt[1 : len(txt)-1]
+	}
+	return txt
+}
+
+func (p FormatterParams) String(name, def string) string {
+	if v, ok := p[name]; ok {
+		return unquote(v)
+	}
+	return def
+}
+
+func (p FormatterParams) Float(name string, def float64) (float64, error) {
+	v, ok := p[name]
+	if !ok {
+		return def, nil
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+func (p FormatterParams) Int(name string, def int) (int, error) {
+	v, ok := p[name]
+	if !ok {
+		return def, nil
+	}
+	return strconv.Atoi(v)
+}
+
+func (p FormatterParams) Bool(name string, def bool) (bool, error) {
+	v, ok := p[name]
+	if !ok {
+		return def, nil
+	}
+	return strconv.ParseBool(v)
+}
+
+// Strings reads a list like [BUY,SELL]
+func (p FormatterParams) Strings(name string, def []string) []string {
+	v, ok := p[name]
+	if !ok {
+		return def
+	}
+	v = strings.TrimSuffix(strings.TrimPrefix(v, "["), "]")
+	ret := make([]string, 0)
+	for _, s := range splitTopLevel(v, ',') {
+		ret = append(ret, unquote(strings.TrimSpace(s)))
+	}
+	return ret
+}
+
+// Floats reads a list like [10,25,50,75]
+func (p FormatterParams) Floats(name string, def []float64) ([]float64, error) {
+	if _, ok := p[name]; !ok {
+		return def, nil
+	}
+	ret := make([]float64, 0)
+	for _, s := range p.Strings(name, nil) {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, f)
+	}
+	return ret, nil
+}
+
+func paramNumberFormat(p FormatterParams) (NumberFormat, error) {
+	nf := DefaultNumberFormat
+	if l, ok := p["locale"]; ok {
+		nf = LocaleNumberFormat(unquote(l))
+	}
+	precision, err := p.Int("precision", nf.Precision)
+	if err != nil {
+		return nf, err
+	}
+	return nf.WithPrecision(precision), nil
+}
+
+func staticFormatter(fn func(f Formatters) FormatterFn) FormatterBuilder {
+	return func(p FormatterParams) (FormatterFn, error) {
+		nf, err := paramNumberFormat(p)
+		if err != nil {
+			return nil, err
+		}
+		return fn(FormattersWith(nf)), nil
+	}
+}
+
+func init() {
+	RegisterFormatter("float", staticFormatter(func(f Formatters) FormatterFn { return f.Float }))
+	RegisterFormatter("int", staticFormatter(func(f Formatters) FormatterFn { return f.Int }))
+	RegisterFormatter("histo_int", staticFormatter(func(f Formatters) FormatterFn { return f.HistoInt }))
+	RegisterFormatter("marked_float", staticFormatter(func(f Formatters) FormatterFn { return f.MarkedFloat }))
+	RegisterFormatter("histo", staticFormatter(func(f Formatters) FormatterFn { return f.Histo }))
+	RegisterFormatter("block", staticFormatter(func(f Formatters) FormatterFn { return f.Block }))
+	RegisterFormatter("histo_block", staticFormatter(func(f Formatters) FormatterFn { return f.HistoBlock }))
+	RegisterFormatter("color_block", staticFormatter(func(f Formatters) FormatterFn { return f.ColorBlock }))
+	RegisterFormatter("relation", staticFormatter(func(f Formatters) FormatterFn { return f.Relation }))
+	RegisterFormatter("bytes", staticFormatter(func(f Formatters) FormatterFn { return f.Bytes }))
+	RegisterFormatter("duration", staticFormatter(func(f Formatters) FormatterFn { return f.Duration }))
+	RegisterFormatter("percent", func(p FormatterParams) (FormatterFn, error) {
+		nf, err := paramNumberFormat(p)
+		if err != nil {
+			return nil, err
+		}
+		return PercentFormatter(nf), nil
+	})
+	RegisterFormatter("categorized", func(p FormatterParams) (FormatterFn, error) {
+		nf, err := paramNumberFormat(p)
+		if err != nil {
+			return nil, err
+		}
+		thresholds, err := p.Floats("thresholds", []float64{20.0, 40.0, 60.0, 80.0})
+		if err != nil {
+			return nil, err
+		}
+		if !sort.Float64sAreSorted(thresholds) {
+			return nil, fmt.Errorf("thresholds %v must be in ascending order", thresholds)
+		}
+		percent, err := p.Bool("percent", false)
+		if err != nil {
+			return nil, err
+		}
+		if percent {
+			return CategorizedFormatter(thresholds, nf.Percent), nil
+		}
+		return CategorizedFormatter(thresholds, nf.Format), nil
+	})
+	RegisterFormatter("buysell", func(p FormatterParams) (FormatterFn, error) {
+		buy, err := p.Float("buy", 1.0)
+		if err != nil {
+			return nil, err
+		}
+		sell, err := p.Float("sell", -1.0)
+		if err != nil {
+			return nil, err
+		}
+		labels := p.Strings("labels", []string{"BUY", "SELL"})
+		if len(labels) != 2 {
+			return nil, fmt.Errorf("expected two labels but got %d", len(labels))
+		}
+		return BuySellFormatter(buy, sell, labels[0], labels[1]), nil
+	})
+	RegisterFormatter("compact", func(p FormatterParams) (FormatterFn, error) {
+		precision, err := p.Int("precision", 1)
+		if err != nil {
+			return nil, err
+		}
+		return func(values []float64, index int) (string, int, int) {
+			return CompactNumber(values[index], precision), 0, 1
+		}, nil
+	})
+	RegisterFormatter("bar", func(p FormatterParams) (FormatterFn, error) {
+		opts := DefaultBarOptions
+		var err error
+		if opts.Width, err = p.Int("width", opts.Width); err != nil {
+			return nil, err
+		}
+		switch p.String("label", "none") {
+		case "beside":
+			opts.Label = BarLabelBeside
+		case "overlay":
+			opts.Label = BarLabelOverlay
+		}
+		return BarFormatter(opts), nil
+	})
+}
+
+// SetFormat sets the formatter used for values added to the column
+// afterwards by AddValues and AddMap. Cells added with AddColumn or the
+// Add* methods of a row keep their own formatting. Use FormatColumn to
+// format existing cells again.
+func (rt *Table) SetFormat(column, spec string) error {
+	idx := rt.FindColumnIndex(column)
+	if idx == -1 {
+		return fmt.Errorf("unknown column %q", column)
+	}
+	fn, err := LookupFormatter(spec)
+	if err != nil {
+		return err
+	}
+	rt.TableHeaders[idx].Format = fn
+	return nil
+}
+
+// ColumnFormats sets the formatters of several columns like SetFormat,
+// for example read from a config file, mapping column names to specs
+func (rt *Table) ColumnFormats(formats map[string]string) error {
+	for column, spec := range formats {
+		if err := rt.SetFormat(column, spec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package table
+
+import "testing"
+
+func TestCategorizedThresholds(t *testing.T) {
+	if _, err := LookupFormatter("categorized(thresholds=[80,20])"); err == nil {
+		t.Error("expected an error for unsorted thresholds")
+	}
+	fn, err := LookupFormatter("categorized(thresholds=[20,80])")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := []float64{10, 50, 90}
+	for i, expected := range []int{MarkerClassA, MarkerClassB, MarkerClassC} {
+		if _, mk, _ := fn(values, i); mk != expected {
+			t.Errorf("value %v: expected marker %d but got %d", values[i], expected, mk)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package table
+
+import "fmt"
+
+// appendCopy appends a copy of the row which belongs to the table
+func (rt *Table) appendCopy(r *Row) *Row {
+	c := *r
+	c.Cells = append([]Cell(nil), r.Cells...)
+	c.table = rt
+	rt.Rows = append(rt.Rows, &c)
+	return &c
+}
+
+func (rt *Table) rebuildKeys() {
+	rt.keys = make(map[string]*Row)
+	for _, r := range rt.Rows {
+		if r.Key != "" {
+			rt.keys[r.Key] = r
+		}
+	}
+}
+
+// FindRow returns the row with the key or nil. The key of a row can also
+// be set directly, so the index is rebuilt if the key is not found.
+func (rt *Table) FindRow(key string) *Row {
+	if rt.keys != nil {
+		if r, ok := rt.keys[key]; ok && r.Key == key {
+			return r
+		}
+	}
+	rt.rebuildKeys()
+	return rt.keys[key]
+}
+
+// RowIndex returns the index of the row with the key or -1
+func (rt *Table) RowIndex(key string) int {
+	r := rt.FindRow(key)
+	if r == nil {
+		return -1
+	}
+	for i, c := range rt.Rows {
+		if c == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// CreateKeyedRow appends a new row which can be found by its key
+func (rt *Table) CreateKeyedRow(key string) *Row {
+	r := rt.CreateRow()
+	r.Key = key
+	if rt.keys != nil {
+		rt.keys[key] = r
+	}
+	return r
+}
+
+// UpsertRow fills the row with the key using fn. The cells of an existing
+// row are cleared before fn is called. Otherwise a new row is appended.
+func (rt *Table) UpsertRow(key string, fn func(r *Row)) *Row {
+	r := rt.FindRow(key)
+	if r == nil {
+		r = rt.CreateKeyedRow(key)
+	} else {
+		r.Cells = nil
+	}
+	fn(r)
+	return r
+}
+
+// InsertRowAt inserts an empty row at the given index. An index equal to
+// the number of rows appends the row.
+func (rt *Table) InsertRowAt(idx int) (*Row, error) {
+	if idx < 0 || idx > len(rt.Rows) {
+		return nil, fmt.Errorf("row index %d out of range [0, %d]", idx, len(rt.Rows))
+	}
+	r := &Row{
+		Size:  len(rt.TableHeaders),
+		table: rt,
+	}
+	rt.Rows = append(rt.Rows, nil)
+	copy(rt.Rows[idx+1:], rt.Rows[idx:])
+	rt.Rows[idx] = r
+	return r, nil
+}
+
+// DeleteRow removes the row at the given index
+func (rt *Table) DeleteRow(idx int) error {
+	if err := rt.checkRow(idx); err != nil {
+		return err
+	}
+	r := rt.Rows[idx]
+	if rt.keys != nil && rt.keys[r.Key] == r {
+		delete(rt.keys, r.Key)
+	}
+	copy(rt.Rows[idx:], rt.Rows[idx+1:])
+	// clear the freed slot so the removed row can be collected
+	rt.Rows[len(rt.Rows)-1] = nil
+	rt.Rows = rt.Rows[:len(rt.Rows)-1]
+	return nil
+}
+
+// DeleteKey removes the row with the key and returns false if there is none
+func (rt *Table) DeleteKey(key string) bool {
+	idx := rt.RowIndex(key)
+	if idx == -1 {
+		return false
+	}
+	rt.DeleteRow(idx)
+	return true
+}
+
+// SetCell replaces the cell. Short rows are padded with empty cells.
+func (rt *Table) SetCell(row, col int, c Cell) error {
+	if err := rt.checkRow(row); err != nil {
+		return err
+	}
+	if err := rt.checkColumn(col); err != nil {
+		return err
+	}
+	r := rt.Rows[row]
+	for len(r.Cells) <= col {
+		r.AddEmpty()
+	}
+	r.Cells[col] = c
+	return nil
+}
@@ -0,0 +1,90 @@
+package table
+
+import "testing"
+
+func TestRowsStayValid(t *testing.T) {
+	tbl := New().Headers("Name", "Value")
+	first := tbl.CreateRow()
+	second := tbl.CreateRow()
+	for i := 0; i < 100; i++ {
+		tbl.CreateRow().AddDefaultText("x").AddInt(i, 0)
+	}
+	second.AddDefaultText("b").AddInt(200, 0)
+	first.AddDefaultText("a").AddInt(300, 0)
+	if tbl.Rows[0].Cells[0].Text != "a" || tbl.Rows[1].Cells[0].Text != "b" {
+		t.Fatal("expected the rows filled out of order to be part of the table")
+	}
+	if tbl.Len() != 102 {
+		t.Errorf("expected 102 rows but got %d", tbl.Len())
+	}
+	sub := tbl.Sub(0, 10)
+	if sub.Len() != 10 {
+		t.Errorf("expected 10 rows in Sub but got %d", sub.Len())
+	}
+	sub.Rows[0].Cells[0].Text = "changed"
+	if first.Cells[0].Text != "a" {
+		t.Error("changing a derived table must not change the source")
+	}
+	filtered := tbl.Filter("Name != x")
+	if filtered.Len() != 2 {
+		t.Errorf("expected 2 rows in Filter but got %d", filtered.Len())
+	}
+	top := tbl.Top(3)
+	if top.Len() != 3 {
+		t.Errorf("expected 3 rows in Top but got %d", top.Len())
+	}
+	if top.Rows[1].Cells[0].Text != "b" {
+		t.Errorf("expected b as second row but got %s", top.Rows[1].Cells[0].Text)
+	}
+}
+
+func TestCountIsLen(t *testing.T) {
+	tbl := New().Headers("Name")
+	tbl.CreateRow().AddDefaultText("a")
+	tbl.CreateRow().AddDefaultText("b")
+	if tbl.Count() != tbl.Len() || tbl.Len() != 2 {
+		t.Errorf("expected 2 rows but got Count %d and Len %d", tbl.Count(), tbl.Len())
+	}
+}
+
+func TestFindRowWithKeySetDirectly(t *testing.T) {
+	tbl := New().Headers("Name")
+	tbl.CreateKeyedRow("A").AddDefaultText("a")
+	if tbl.FindRow("A") == nil {
+		t.Fatal("expected to find row A")
+	}
+	r := tbl.CreateRow()
+	r.Key = "B"
+	if tbl.FindRow("B") != r {
+		t.Error("expected to find row B")
+	}
+	r.Key = "C"
+	if tbl.FindRow("B") != nil {
+		t.Error("expected no row B after changing the key")
+	}
+	if tbl.FindRow("C") != r {
+		t.Error("expected to find row C")
+	}
+	if tbl.RowIndex("C") != 1 {
+		t.Errorf("expected index 1 but got %d", tbl.RowIndex("C"))
+	}
+}
+
+func TestDeleteRowClearsSlot(t *testing.T) {
+	tbl := New().Headers("Name")
+	for _, n := range []string{"a", "b", "c"} {
+		tbl.CreateRow().AddDefaultText(n)
+	}
+	if err := tbl.DeleteRow(0); err != nil {
+		t.Fatal(err)
+	}
+	if tbl.Len() != 2 || tbl.Rows[0].Cells[0].Text != "b" || tbl.Rows[1].Cells[0].Text != "c" {
+		t.Errorf("unexpected rows after delete\n%s", tbl.Plain())
+	}
+	if tail := tbl.Rows[:3][2]; tail != nil {
+		t.Error("expected the freed slot to be cleared")
+	}
+	if err := tbl.DeleteRow(5); err == nil {
+		t.Error("expected an error for an invalid row")
+	}
+}
@@ -0,0 +1,201 @@
+package table
+
+import (
+	"regexp"
+	"time"
+)
+
+// RuleFn decides if a rule applies to the cell
+type RuleFn func(c Cell, stats ColumnStats) bool
+
+// Rule sets the marker of a cell at render time if Match returns true.
+// The rules comparing values never match text cells.
+type Rule struct {
+	Match  RuleFn
+	Marker int
+}
+
+func LessThan(v float64, marker int) Rule {
+	return Rule{
+		Match: func(c Cell, stats ColumnStats) bool {
+			return !c.text && c.Value < v
+		},
+		Marker: marker,
+	}
+}
+
+func GreaterThan(v float64, marker int) Rule {
+	return Rule{
+		Match: func(c Cell, stats ColumnStats) bool {
+			return !c.text && c.Value > v
+		},
+		Marker: marker,
+	}
+}
+
+func Equals(v float64, marker int) Rule {
+	return Rule{
+		Match: func(c Cell, stats ColumnStats) bool {
+			return !c.text && c.Value == v
+		},
+		Marker: marker,
+	}
+}
+
+// Between matches values within [min, max)
+func Between(min, max float64, marker int) Rule {
+	return Rule{
+		Match: func(c Cell, stats ColumnStats) bool {
+			return !c.text && c.Value >= min && c.Value < max
+		},
+		Marker: marker,
+	}
+}
+
+// MatchText matches the text of the cell against the regular expression.
+// It panics if the expression cannot be compiled.
+func MatchText(pattern string, marker int) Rule {
+	re := regexp.MustCompile(pattern)
+	return Rule{
+		Match: func(c Cell, stats ColumnStats) bool {
+			return re.MatchString(c.Text)
+		},
+		Marker: marker,
+	}
+}
+
+// TopPercent matches the highest p percent of the values of the column
+func TopPercent(p float64, marker int) Rule {
+	return Rule{
+		Match: func(c Cell, stats ColumnStats) bool {
+			return !c.text && stats.Count > 0 && c.Value >= stats.Percentile(100.0-p)
+		},
+		Marker: marker,
+	}
+}
+
+// BottomPercent matches the lowest p percent of the values of the column
+func BottomPercent(p float64, marker int) Rule {
+	return Rule{
+		Match: func(c Cell, stats ColumnStats) bool {
+			return !c.text && stats.Count > 0 && c.Value <= stats.Percentile(p)
+		},
+		Marker: marker,
+	}
+}
+
+// AddRule attaches rules to a column. The first matching rule
+// defines the marker of a cell when the table is rendered.
+func (rt *Table) AddRule(column string, rules ...Rule) *Table {
+	idx := rt.FindColumnIndex(column)
+	if idx != -1 {
+		rt.TableHeaders[idx].Rules = append(rt.TableHeaders[idx].Rules, rules...)
+	}
+	return rt
+}
+
+// ClearRules removes all rules of the column
+func (rt *Table) ClearRules(column string) *Table {
+	idx := rt.FindColumnIndex(column)
+	if idx != -1 {
+		rt.TableHeaders[idx].Rules = nil
+	}
+	return rt
+}
+
+func applyRules(c Cell, rules []Rule, stats ColumnStats) int {
+	for _, r := range rules {
+		if r.Match(c, stats) {
+			return r.Marker
+		}
+	}
+	return c.Marker
+}
+
+func (rt *Table) decorated() bool {
+	for _, h := range rt.TableHeaders {
+		if len(h.Rules) > 0 || h.Scale != nil || (h.Format != nil && h.Expr == nil) {
+			return true
+		}
+	}
+	for _, r := range rt.Rows {
+		for _, c := range r.Cells {
+			if c.bar != nil || c.relative {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// view returns the rows as they should be rendered with all column
+// formatters, rules, color scales and bars applied. Without gradients
+// the scales fall back to markers.
+func (rt *Table) view(gradients bool) []*Row {
+	rt.Recompute()
+	if !rt.decorated() {
+		return rt.Rows
+	}
+	stats := make(map[int]ColumnStats)
+	columnStats := func(idx int) ColumnStats {
+		if cs, ok := stats[idx]; ok {
+			return cs
+		}
+		cs := NewColumnStats(rt.ColumnValues(idx))
+		stats[idx] = cs
+		return cs
+	}
+	series := make(map[int][]float64)
+	columnValues := func(idx int) []float64 {
+		if v, ok := series[idx]; ok {
+			return v
+		}
+		v := make([]float64, len(rt.Rows))
+		for i, r := range rt.Rows {
+			v[i] = cellValue(r, idx)
+		}
+		series[idx] = v
+		return v
+	}
+	ret := make([]*Row, len(rt.Rows))
+	for i, r := range rt.Rows {
+		c := *r
+		ret[i] = &c
+		ret[i].Cells = make([]Cell, len(r.Cells))
+		copy(ret[i].Cells, r.Cells)
+		for j := range ret[i].Cells {
+			c := &ret[i].Cells[j]
+			if c.relative {
+				c.Text = RelativeTime(time.Unix(int64(c.Value), 0), time.Now())
+			}
+			if c.bar != nil && !c.bar.Fixed {
+				cs := columnStats(j)
+				c.Text = c.bar.render(c.Value, cs.Min, cs.Max)
+			}
+			if j >= len(rt.TableHeaders) {
+				continue
+			}
+			h := rt.TableHeaders[j]
+			if c.series && h.Format != nil && h.Expr == nil {
+				txt, mk, al := h.Format(columnValues(j), i)
+				c.Text, c.Marker, c.Alignment = txt, mk, TextAlign(al)
+			}
+			if len(h.Rules) > 0 {
+				c.Marker = applyRules(*c, h.Rules, columnStats(j))
+			}
+			if h.Scale != nil {
+				if gradients {
+					clr := h.Scale.Color(c.Value, columnStats(j)).Hex()
+					if h.Scale.Background {
+						c.bg = clr
+					} else {
+						c.fg = clr
+					}
+				} else {
+					c.Marker = h.Scale.Marker(c.Value, columnStats(j))
+				}
+			}
+		}
+	}
+	return ret
+}
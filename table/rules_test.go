@@ -0,0 +1,76 @@
+package table
+
+import "testing"
+
+func TestRules(t *testing.T) {
+	stats := NewColumnStats([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	num := func(v float64) Cell { return Cell{Value: v} }
+	txt := Cell{Text: "n/a", text: true}
+	tests := []struct {
+		name     string
+		rule     Rule
+		cell     Cell
+		expected bool
+	}{
+		{"less", LessThan(5, 1), num(4), true},
+		{"less equal", LessThan(5, 1), num(5), false},
+		{"less text", LessThan(5, 1), txt, false},
+		{"greater", GreaterThan(5, 1), num(6), true},
+		{"greater equal", GreaterThan(5, 1), num(5), false},
+		{"equals", Equals(5, 1), num(5), true},
+		{"equals text", Equals(0, 1), txt, false},
+		{"between min", Between(2, 4, 1), num(2), true},
+		{"between max", Between(2, 4, 1), num(4), false},
+		{"text", MatchText("^n/", 1), txt, true},
+		{"text no match", MatchText("^x", 1), txt, false},
+		{"top", TopPercent(20, 1), num(9), true},
+		{"top miss", TopPercent(20, 1), num(8), false},
+		{"top text", TopPercent(100, 1), txt, false},
+		{"bottom", BottomPercent(20, 1), num(2), true},
+		{"bottom miss", BottomPercent(20, 1), num(3), false},
+		{"bottom text", BottomPercent(20, 1), txt, false},
+	}
+	for _, tc := range tests {
+		if got := tc.rule.Match(tc.cell, stats); got != tc.expected {
+			t.Errorf("%s: expected %v but got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestRulesOnMixedColumn(t *testing.T) {
+	tbl := New().Headers("Value")
+	for _, v := range []float64{10, 20, 30, 40} {
+		tbl.CreateRow().AddFloat(v, 0)
+	}
+	for i := 0; i < 4; i++ {
+		tbl.CreateRow().AddDefaultText("-")
+	}
+	if cs := tbl.ColumnStats("Value"); cs.Count != 4 || cs.Min != 10 || cs.Mean != 25 {
+		t.Errorf("expected the text cells to be skipped but got %+v", cs)
+	}
+	tbl.AddRule("Value", TopPercent(25, MarkerPositive), BottomPercent(25, MarkerNegative))
+	expected := []int{MarkerNegative, 0, 0, MarkerPositive, 0, 0, 0, 0}
+	for i, r := range tbl.view(false) {
+		if r.Cells[0].Marker != expected[i] {
+			t.Errorf("row %d: expected marker %d but got %d", i, expected[i], r.Cells[0].Marker)
+		}
+	}
+	if tbl.Rows[0].Cells[0].Marker != 0 {
+		t.Error("rules must not change the cells of the table")
+	}
+}
+
+func TestFirstMatchingRuleWins(t *testing.T) {
+	tbl := New().Headers("Value")
+	tbl.CreateRow().AddFloat(5, MarkerClassC)
+	tbl.CreateRow().AddFloat(50, MarkerClassC)
+	tbl.AddRule("Value", GreaterThan(10, MarkerPositive), GreaterThan(1, MarkerNegative))
+	rows := tbl.view(false)
+	if rows[0].Cells[0].Marker != MarkerNegative || rows[1].Cells[0].Marker != MarkerPositive {
+		t.Errorf("unexpected markers %d %d", rows[0].Cells[0].Marker, rows[1].Cells[0].Marker)
+	}
+	tbl.ClearRules("Value")
+	if tbl.view(false)[0].Cells[0].Marker != MarkerClassC {
+		t.Error("expected the own marker after ClearRules")
+	}
+}
@@ -0,0 +1,141 @@
+package table
+
+import "github.com/amecky/table/term"
+
+// ColorScale maps the values of a column to a continuous color gradient
+type ColorScale struct {
+	Colors     []string
+	Min        float64
+	Mid        float64
+	Max        float64
+	Auto       bool
+	Lab        bool
+	Background bool
+}
+
+// SequentialScale creates a gradient from the lowest to the highest value
+func SequentialScale(from, to string) *ColorScale {
+	return &ColorScale{
+		Colors: []string{from, to},
+		Auto:   true,
+	}
+}
+
+// DivergingScale creates a gradient with a center value (0 by default)
+func DivergingScale(low, mid, high string) *ColorScale {
+	return &ColorScale{
+		Colors: []string{low, mid, high},
+		Auto:   true,
+	}
+}
+
+// Range sets a fixed range instead of using the min/max of the column
+func (s *ColorScale) Range(min, max float64) *ColorScale {
+	s.Min = min
+	s.Max = max
+	s.Auto = false
+	return s
+}
+
+// Center sets the value of the middle color of a diverging scale
+func (s *ColorScale) Center(mid float64) *ColorScale {
+	s.Mid = mid
+	return s
+}
+
+// InLab interpolates in the L*a*b* color space instead of RGB
+func (s *ColorScale) InLab() *ColorScale {
+	s.Lab = true
+	return s
+}
+
+// OnBackground colors the background instead of the text
+func (s *ColorScale) OnBackground() *ColorScale {
+	s.Background = true
+	return s
+}
+
+func (s *ColorScale) bounds(stats ColumnStats) (float64, float64) {
+	if s.Auto {
+		return stats.Min, stats.Max
+	}
+	return s.Min, s.Max
+}
+
+// Position returns the relative position (0 - 1) of the value on the scale
+func (s *ColorScale) Position(v float64, stats ColumnStats) float64 {
+	min, max := s.bounds(stats)
+	if len(s.Colors) > 2 {
+		if v < s.Mid {
+			if s.Mid == min {
+				return 0.5
+			}
+			return clamp((v-min)/(s.Mid-min)) * 0.5
+		}
+		if max == s.Mid {
+			return 0.5
+		}
+		return 0.5 + clamp((v-s.Mid)/(max-s.Mid))*0.5
+	}
+	if max == min {
+		return 0.5
+	}
+	return clamp((v - min) / (max - min))
+}
+
+// Color returns the interpolated color of the value
+func (s *ColorScale) Color(v float64, stats ColumnStats) term.Color {
+	if len(s.Colors) == 0 {
+		return term.Color{}
+	}
+	if len(s.Colors) == 1 {
+		return term.Hex(s.Colors[0])
+	}
+	t := s.Position(v, stats) * float64(len(s.Colors)-1)
+	idx := int(t)
+	if idx >= len(s.Colors)-1 {
+		idx = len(s.Colors) - 2
+	}
+	from := term.Hex(s.Colors[idx])
+	to := term.Hex(s.Colors[idx+1])
+	if s.Lab {
+		return term.BlendLab(from, to, t-float64(idx))
+	}
+	return term.BlendRGB(from, to, t-float64(idx))
+}
+
+// Marker returns one of the class markers A - E as fallback for terminals
+// which cannot display the gradient
+func (s *ColorScale) Marker(v float64, stats ColumnStats) int {
+	idx := int(s.Position(v, stats) * 5.0)
+	if idx > 4 {
+		idx = 4
+	}
+	return MarkerClassA + idx
+}
+
+func clamp(v float64) float64 {
+	if v < 0.0 {
+		return 0.0
+	}
+	if v > 1.0 {
+		return 1.0
+	}
+	return v
+}
+
+// ColorScale attaches a color scale to a column
+func (rt *Table) ColorScale(column string, s *ColorScale) *Table {
+	idx := rt.FindColumnIndex(column)
+	if idx != -1 {
+		rt.TableHeaders[idx].Scale = s
+	}
+	return rt
+}
+
+// ColorProfile overrides the detected color profile. Color scales will
+// fall back to markers unless the profile is TrueColor
+func (rt *Table) ColorProfile(p term.Profile) *Table {
+	rt.profile = p
+	return rt
+}
@@ -0,0 +1,99 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amecky/table/term"
+)
+
+func TestScalePosition(t *testing.T) {
+	stats := NewColumnStats([]float64{-10, 0, 10, 30})
+	tests := []struct {
+		name     string
+		scale    *ColorScale
+		value    float64
+		expected float64
+	}{
+		{"sequential min", SequentialScale("#000000", "#ffffff"), -10, 0},
+		{"sequential max", SequentialScale("#000000", "#ffffff"), 30, 1},
+		{"sequential mid", SequentialScale("#000000", "#ffffff"), 10, 0.5},
+		{"fixed range", SequentialScale("#000000", "#ffffff").Range(0, 100), 25, 0.25},
+		{"fixed range clamped", SequentialScale("#000000", "#ffffff").Range(0, 100), -10, 0},
+		{"diverging center", DivergingScale("#ff0000", "#ffffff", "#00ff00"), 0, 0.5},
+		{"diverging low", DivergingScale("#ff0000", "#ffffff", "#00ff00"), -5, 0.25},
+		{"diverging high", DivergingScale("#ff0000", "#ffffff", "#00ff00"), 15, 0.75},
+		{"diverging moved center", DivergingScale("#ff0000", "#ffffff", "#00ff00").Center(10), 10, 0.5},
+	}
+	for _, tc := range tests {
+		if got := tc.scale.Position(tc.value, stats); got != tc.expected {
+			t.Errorf("%s: expected %v but got %v", tc.name, tc.expected, got)
+		}
+	}
+	if got := SequentialScale("#000000", "#ffffff").Position(5, NewColumnStats([]float64{5, 5})); got != 0.5 {
+		t.Errorf("expected 0.5 for a constant column but got %v", got)
+	}
+}
+
+func TestScaleColor(t *testing.T) {
+	stats := NewColumnStats([]float64{0, 100})
+	rgb := SequentialScale("#000000", "#ffffff")
+	lab := SequentialScale("#000000", "#ffffff").InLab()
+	if got := rgb.Color(0, stats).Hex(); got != "#000000" {
+		t.Errorf("expected black but got %s", got)
+	}
+	if got := rgb.Color(100, stats).Hex(); got != "#ffffff" {
+		t.Errorf("expected white but got %s", got)
+	}
+	if got := rgb.Color(50, stats).Hex(); got != "#808080" {
+		t.Errorf("expected #808080 but got %s", got)
+	}
+	if got := lab.Color(50, stats).Hex(); got == "#808080" {
+		t.Errorf("expected Lab to blend differently than RGB but got %s", got)
+	}
+	diverging := DivergingScale("#ff0000", "#ffffff", "#00ff00").Range(-1, 1)
+	if got := diverging.Color(0, stats).Hex(); got != "#ffffff" {
+		t.Errorf("expected the middle color but got %s", got)
+	}
+}
+
+func TestScaleMarkers(t *testing.T) {
+	stats := NewColumnStats([]float64{0, 100})
+	s := SequentialScale("#000000", "#ffffff")
+	for v, expected := range map[float64]int{0: MarkerClassA, 25: MarkerClassB, 50: MarkerClassC, 70: MarkerClassD, 100: MarkerClassE} {
+		if got := s.Marker(v, stats); got != expected {
+			t.Errorf("%v: expected %d but got %d", v, expected, got)
+		}
+	}
+}
+
+func scaleTable(p term.Profile) *Table {
+	tbl := New().Headers("Value").ColorProfile(p)
+	for _, v := range []float64{0, 50, 100} {
+		tbl.CreateRow().AddFloat(v, 0)
+	}
+	tbl.ColorScale("Value", SequentialScale("#000000", "#ffffff").OnBackground())
+	return tbl
+}
+
+func TestScaleFallsBackToMarkers(t *testing.T) {
+	for _, p := range []term.Profile{term.Ascii, term.ANSI, term.ANSI256} {
+		tbl := scaleTable(p)
+		rows := tbl.view(tbl.profile == term.TrueColor)
+		markers := []int{rows[0].Cells[0].Marker, rows[1].Cells[0].Marker, rows[2].Cells[0].Marker}
+		if markers[0] != MarkerClassA || markers[1] != MarkerClassC || markers[2] != MarkerClassE {
+			t.Errorf("profile %d: unexpected markers %v", p, markers)
+		}
+		if rows[1].Cells[0].bg != "" {
+			t.Errorf("profile %d: expected no gradient color", p)
+		}
+	}
+	tbl := scaleTable(term.TrueColor)
+	rows := tbl.view(true)
+	if rows[1].Cells[0].bg != "#808080" || rows[1].Cells[0].Marker != 0 {
+		t.Errorf("expected a background gradient but got %q", rows[1].Cells[0].bg)
+	}
+	if !strings.Contains(tbl.String(), "48;2;128;128;128") {
+		t.Error("expected the gradient color in the output")
+	}
+}
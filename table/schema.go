@@ -0,0 +1,219 @@
+package table
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amecky/table/term"
+)
+
+type ColumnType int
+
+const (
+	// TextColumn shows the value as plain text
+	TextColumn ColumnType = iota
+	// IntColumn shows numbers without decimals
+	IntColumn
+	// FloatColumn shows numbers with two decimals
+	FloatColumn
+	// PercentColumn shows numbers as percentage
+	PercentColumn
+	// TimeColumn shows time.Time values
+	TimeColumn
+	// BoolColumn shows booleans
+	BoolColumn
+)
+
+// Column creates a typed column definition. Numeric columns are
+// right aligned and missing values are shown as "-".
+func Column(name string, ct ColumnType) TableHeader {
+	ret := TableHeader{
+		Text:  name,
+		Type:  ct,
+		Align: AlignLeft,
+		Null:  "-",
+	}
+	if ct == IntColumn || ct == FloatColumn || ct == PercentColumn || ct == TimeColumn {
+		ret.Align = AlignRight
+	}
+	return ret
+}
+
+// WithFormat sets the formatter which defines text, marker and alignment
+// of numeric values. The formatter receives all values of the column when
+// the table is rendered so formatters comparing values like Histo work.
+func (h TableHeader) WithFormat(fn FormatterFn) TableHeader {
+	h.Format = fn
+	return h
+}
+
+func (h TableHeader) WithAlign(align TextAlign) TableHeader {
+	h.Align = align
+	return h
+}
+
+// WithWidth limits the width of the column. 0 means no limit
+func (h TableHeader) WithWidth(min, max int) TableHeader {
+	h.MinWidth = min
+	h.MaxWidth = max
+	return h
+}
+
+// WithNull sets the text shown for nil values
+func (h TableHeader) WithNull(txt string) TableHeader {
+	h.Null = txt
+	return h
+}
+
+func (h TableHeader) WithRules(rules ...Rule) TableHeader {
+	h.Rules = append(h.Rules, rules...)
+	return h
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case bool:
+		if n {
+			return 1.0, true
+		}
+		return 0.0, true
+	case time.Time:
+		return float64(n.Unix()), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0.0, false
+}
+
+// FormatValue converts a raw value into a cell according to the column definition
+func (h TableHeader) FormatValue(v interface{}) Cell {
+	return h.formatValue(v, DefaultNumberFormat, func(t time.Time) string {
+		return t.Format(DefaultTimeLayout)
+	})
+}
+
+func (h TableHeader) formatValue(v interface{}, nf NumberFormat, tf func(t time.Time) string) Cell {
+	switch c := v.(type) {
+	case nil:
+		return Cell{Text: h.Null, Alignment: h.Align, text: true}
+	case Cell:
+		return c
+	case MarkedText:
+		return Cell{Text: c.Text, Marker: c.Marker, Alignment: h.Align, text: true}
+	}
+	f, numeric := toFloat(v)
+	if h.Format != nil && numeric {
+		// the text is formatted again with the whole column when the table is rendered
+		txt, mk, al := h.Format([]float64{f}, 0)
+		return Cell{Text: txt, Marker: mk, Alignment: TextAlign(al), Value: f, series: true}
+	}
+	ret := Cell{Alignment: h.Align, Value: f, text: !numeric}
+	switch h.Type {
+	case IntColumn:
+		if numeric {
+			ret.Text = nf.Int(f)
+			return ret
+		}
+	case FloatColumn:
+		if numeric {
+			ret.Text = nf.Format(f)
+			return ret
+		}
+	case PercentColumn:
+		if numeric {
+			ret.Text = nf.Percent(f)
+			return ret
+		}
+	case TimeColumn:
+		if t, ok := v.(time.Time); ok {
+			ret.Text = tf(t)
+			return ret
+		}
+	case BoolColumn:
+		if b, ok := v.(bool); ok {
+			ret.Text = fmt.Sprintf("%t", b)
+			return ret
+		}
+	}
+	ret.Text = fmt.Sprint(v)
+	return ret
+}
+
+// AddValues adds cells for the remaining columns of the row formatted by
+// the column definitions. Nothing is added if the number of values does
+// not match the number of remaining columns.
+func (tr *Row) AddValues(values ...interface{}) error {
+	if tr.table == nil {
+		return fmt.Errorf("row does not belong to a table")
+	}
+	headers := tr.table.TableHeaders
+	start := len(tr.Cells)
+	if start+len(values) != len(headers) {
+		return fmt.Errorf("got %d values but row has %d remaining columns", len(values), len(headers)-start)
+	}
+	for i, v := range values {
+		tr.Cells = append(tr.Cells, headers[start+i].formatValue(v, tr.numberFormat(), tr.timeFormat))
+	}
+	return nil
+}
+
+// AddMap adds cells for the remaining columns of the row taking the
+// values by column name. Missing values are shown as null.
+func (tr *Row) AddMap(values map[string]interface{}) error {
+	if tr.table == nil {
+		return fmt.Errorf("row does not belong to a table")
+	}
+	headers := tr.table.TableHeaders
+	start := len(tr.Cells)
+	for k := range values {
+		idx := tr.table.FindColumnIndex(k)
+		if idx == -1 {
+			return fmt.Errorf("unknown column %q", k)
+		}
+		if idx < start {
+			return fmt.Errorf("column %q is already set", k)
+		}
+	}
+	for i := start; i < len(headers); i++ {
+		tr.Cells = append(tr.Cells, headers[i].formatValue(values[headers[i].Text], tr.numberFormat(), tr.timeFormat))
+	}
+	return nil
+}
+
+// truncate shortens the text to the given length using an ellipsis
+func truncate(txt string, length int) string {
+	if length <= 0 || internalLen(txt) <= length {
+		return txt
+	}
+	if length == 1 {
+		return "…"
+	}
+	return term.Truncate(txt, length-1) + "…"
+}
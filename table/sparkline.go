@@ -0,0 +1,194 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amecky/table/term"
+)
+
+var sparkBlocks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// braille dots of the left and right column ordered from bottom to top
+var (
+	brailleLeft  = []rune{0x40, 0x04, 0x02, 0x01}
+	brailleRight = []rune{0x80, 0x20, 0x10, 0x08}
+)
+
+// SparklineOptions define how a sparkline is drawn. Width limits the
+// sparkline to the most recent values (0 uses all values). MinMax draws
+// the lowest point using the style of the negative marker and the highest
+// point using the style of the positive marker. Labels appends the lowest
+// and the highest value like "↓1.00 ↑3.00" using the format.
+type SparklineOptions struct {
+	Width   int
+	MinMax  bool
+	Labels  bool
+	Braille bool
+	Format  string
+}
+
+var DefaultSparklineOptions = SparklineOptions{
+	Format: "%.2f",
+}
+
+// sparkPoints are the indices of the characters showing the lowest and the highest value
+type sparkPoints struct {
+	min int
+	max int
+}
+
+// sparkRange returns the range of the finite values and the indices of
+// the lowest and the highest one or -1 if there are no finite values
+func sparkRange(values []float64) (float64, float64, int, int) {
+	min := math.Inf(1)
+	max := math.Inf(-1)
+	minIdx, maxIdx := -1, -1
+	for i, v := range values {
+		if !isFinite(v) {
+			continue
+		}
+		if v < min {
+			min = v
+			minIdx = i
+		}
+		if v > max {
+			max = v
+			maxIdx = i
+		}
+	}
+	return min, max, minIdx, maxIdx
+}
+
+func sparkLevel(v, min, max float64, levels int) int {
+	if max == min {
+		return levels / 2
+	}
+	return int(math.Round((v - min) / (max - min) * float64(levels-1)))
+}
+
+// Sparkline draws the values using block characters. In braille mode
+// every character contains two values. NaN and infinite values are left blank.
+func Sparkline(values []float64, braille bool) string {
+	if len(values) == 0 {
+		return ""
+	}
+	min, max, _, _ := sparkRange(values)
+	sb := strings.Builder{}
+	if braille {
+		dots := func(v float64, column []rune) rune {
+			r := rune(0)
+			if !isFinite(v) {
+				return r
+			}
+			for k := 0; k <= sparkLevel(v, min, max, 4); k++ {
+				r |= column[k]
+			}
+			return r
+		}
+		for i := 0; i < len(values); i += 2 {
+			r := rune(0x2800) | dots(values[i], brailleLeft)
+			if i+1 < len(values) {
+				r |= dots(values[i+1], brailleRight)
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String()
+	}
+	for _, v := range values {
+		if !isFinite(v) {
+			sb.WriteRune(' ')
+			continue
+		}
+		sb.WriteRune(sparkBlocks[sparkLevel(v, min, max, len(sparkBlocks))])
+	}
+	return sb.String()
+}
+
+// AddSparkline adds a sparkline of the values. The cell is marked
+// positive or negative depending on the change of the last value
+// and keeps the last value for sorting. Values which are not finite
+// are left out.
+func (tr *Row) AddSparkline(values []float64, opts SparklineOptions) *Row {
+	if opts.Width > 0 && len(values) > opts.Width {
+		values = values[len(values)-opts.Width:]
+	}
+	txt := Sparkline(values, opts.Braille)
+	finite := make([]float64, 0, len(values))
+	for _, v := range values {
+		if isFinite(v) {
+			finite = append(finite, v)
+		}
+	}
+	marker := MarkerNone
+	last := 0.0
+	if len(finite) > 0 {
+		last = finite[len(finite)-1]
+	}
+	if len(finite) > 1 {
+		prev := finite[len(finite)-2]
+		if last > prev {
+			marker = MarkerPositive
+		} else if last < prev {
+			marker = MarkerNegative
+		}
+	}
+	min, max, minIdx, maxIdx := sparkRange(values)
+	var points *sparkPoints
+	if opts.MinMax && minIdx != -1 && min != max {
+		points = &sparkPoints{min: minIdx, max: maxIdx}
+		if opts.Braille {
+			points.min /= 2
+			points.max /= 2
+		}
+	}
+	if opts.Labels && minIdx != -1 {
+		format := opts.Format
+		if format == "" {
+			format = DefaultSparklineOptions.Format
+		}
+		txt += fmt.Sprintf(" ↓"+format+" ↑"+format, min, max)
+	}
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      txt,
+		Marker:    marker,
+		Alignment: AlignLeft,
+		Value:     last,
+		text:      len(finite) == 0,
+		spark:     points,
+	})
+	return tr
+}
+
+// appendSparkline writes the padded text of a sparkline cell. The lowest
+// and the highest point use the styles of the negative and positive marker.
+func (rt *Table) appendSparkline(txt string, c Cell, st term.Style, marker func(mk int) term.Style) {
+	appendText := func(t string, style term.Style) {
+		if t != "" {
+			rt.cr.Append(t, style)
+		}
+	}
+	// the sparkline starts after the spaces added by the alignment
+	offset := 0
+	for offset < len(txt) && txt[offset] == ' ' && !strings.HasPrefix(txt[offset:], c.Text) {
+		offset++
+	}
+	appendText(txt[:offset], st)
+	rest := txt[offset:]
+	start, idx := 0, 0
+	for pos, r := range rest {
+		if idx == c.spark.min || idx == c.spark.max {
+			appendText(rest[start:pos], st)
+			mk := MarkerPositive
+			if idx == c.spark.min {
+				mk = MarkerNegative
+			}
+			appendText(string(r), marker(mk))
+			start = pos + utf8.RuneLen(r)
+		}
+		idx++
+	}
+	appendText(rest[start:], st)
+}
@@ -0,0 +1,76 @@
+package table
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/amecky/table/term"
+)
+
+func TestSparkline(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name     string
+		values   []float64
+		braille  bool
+		expected string
+	}{
+		{"empty", nil, false, ""},
+		{"blocks", []float64{1, 2, 3, 4, 5, 6, 7, 8}, false, "▁▂▃▄▅▆▇█"},
+		{"flat", []float64{2, 2, 2}, false, "▅▅▅"},
+		{"nan", []float64{1, nan, 3}, false, "▁ █"},
+		{"inf", []float64{1, math.Inf(1), 3, math.Inf(-1)}, false, "▁ █ "},
+		{"only nan", []float64{nan, nan}, false, "  "},
+		{"braille", []float64{1, 4, 4, 1}, true, "⣸⣇"},
+		{"braille odd", []float64{1, 4, 4}, true, "⣸⡇"},
+		{"braille nan", []float64{1, nan, nan, 4}, true, "⡀⢸"},
+	}
+	for _, tc := range tests {
+		if got := Sparkline(tc.values, tc.braille); got != tc.expected {
+			t.Errorf("%s: expected %q but got %q", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestAddSparkline(t *testing.T) {
+	tbl := New().Headers("Name", "Trend")
+	tbl.CreateRow().AddDefaultText("up").AddSparkline([]float64{1, 2, 3}, DefaultSparklineOptions)
+	tbl.CreateRow().AddDefaultText("down").AddSparkline([]float64{3, 2, math.NaN()}, SparklineOptions{Labels: true})
+	tbl.CreateRow().AddDefaultText("last").AddSparkline([]float64{9, 1, 2, 3, 4}, SparklineOptions{Width: 3})
+	up, down, last := tbl.Rows[0].Cells[1], tbl.Rows[1].Cells[1], tbl.Rows[2].Cells[1]
+	if up.Marker != MarkerPositive || up.Value != 3 {
+		t.Errorf("unexpected cell %+v", up)
+	}
+	if down.Marker != MarkerNegative || down.Value != 2 || down.Text != "█▁  ↓2.00 ↑3.00" {
+		t.Errorf("unexpected cell %+v", down)
+	}
+	if last.Text != "▁▅█" {
+		t.Errorf("expected the last 3 values but got %q", last.Text)
+	}
+	if tbl.Plain() == "" {
+		t.Error("expected the table to render")
+	}
+}
+
+func TestSparklineMinMaxPoints(t *testing.T) {
+	tbl := New().Headers("Trend").ColorProfile(term.TrueColor)
+	tbl.CreateRow().AddSparkline([]float64{2, 5, 1, 3}, SparklineOptions{MinMax: true})
+	c := tbl.Rows[0].Cells[0]
+	if c.spark == nil || c.spark.min != 2 || c.spark.max != 1 {
+		t.Fatalf("unexpected points %+v", c.spark)
+	}
+	negative := tbl.Markers().Get(MarkerNegative).Striped.Convert("▁")
+	positive := tbl.Markers().Get(MarkerPositive).Striped.Convert("█")
+	out := tbl.String()
+	if !strings.Contains(out, negative) || !strings.Contains(out, positive) {
+		t.Errorf("expected the lowest and highest point to be marked in %q", out)
+	}
+	if !strings.Contains(tbl.Plain(), "│ ▃█▁▅ + │") {
+		t.Errorf("unexpected plain output\n%s", tbl.Plain())
+	}
+	tbl.CreateRow().AddSparkline([]float64{1, 4, 4, 1, 5}, SparklineOptions{MinMax: true, Braille: true})
+	if c := tbl.Rows[1].Cells[0]; c.spark.min != 0 || c.spark.max != 2 {
+		t.Errorf("expected the points of the braille characters but got %+v", c.spark)
+	}
+}
@@ -0,0 +1,109 @@
+package table
+
+import (
+	"math"
+	"sort"
+)
+
+// ColumnStats contains some basic statistics of the values of a column
+type ColumnStats struct {
+	Count  int
+	Min    float64
+	Max    float64
+	Sum    float64
+	Mean   float64
+	StdDev float64
+	sorted []float64
+}
+
+// NewColumnStats builds the statistics of the values. NaN and infinite
+// values are ignored.
+func NewColumnStats(values []float64) ColumnStats {
+	finite := make([]float64, 0, len(values))
+	for _, v := range values {
+		if isFinite(v) {
+			finite = append(finite, v)
+		}
+	}
+	values = finite
+	ret := ColumnStats{
+		Count:  len(values),
+		sorted: values,
+	}
+	sort.Float64s(ret.sorted)
+	if len(values) > 0 {
+		ret.Min = ret.sorted[0]
+		ret.Max = ret.sorted[len(values)-1]
+		for _, v := range values {
+			ret.Sum += v
+		}
+		ret.Mean = ret.Sum / float64(len(values))
+		sq := 0.0
+		for _, v := range values {
+			sq += (v - ret.Mean) * (v - ret.Mean)
+		}
+		ret.StdDev = math.Sqrt(sq / float64(len(values)))
+	}
+	return ret
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// Median returns the 50th percentile
+func (cs ColumnStats) Median() float64 {
+	return cs.Percentile(50.0)
+}
+
+// Rank returns the fraction (0 - 1) of values which are less than v
+func (cs ColumnStats) Rank(v float64) float64 {
+	if cs.Count < 2 {
+		return 0.0
+	}
+	idx := sort.SearchFloat64s(cs.sorted, v)
+	return float64(idx) / float64(cs.Count-1)
+}
+
+// ZScore returns the distance of v from the mean in standard deviations
+func (cs ColumnStats) ZScore(v float64) float64 {
+	if cs.StdDev == 0.0 {
+		return 0.0
+	}
+	return (v - cs.Mean) / cs.StdDev
+}
+
+// Percentile returns the value below which p percent (0 - 100) of the values fall
+func (cs ColumnStats) Percentile(p float64) float64 {
+	if cs.Count == 0 {
+		return 0.0
+	}
+	if p <= 0.0 {
+		return cs.Min
+	}
+	if p >= 100.0 {
+		return cs.Max
+	}
+	pos := p / 100.0 * float64(cs.Count-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	f := pos - float64(lo)
+	return cs.sorted[lo] + (cs.sorted[hi]-cs.sorted[lo])*f
+}
+
+// ColumnValues returns the values of all cells of the given column. Text
+// cells without a numeric value are skipped.
+func (rt *Table) ColumnValues(idx int) []float64 {
+	ret := make([]float64, 0, len(rt.Rows))
+	for _, r := range rt.Rows {
+		if idx >= 0 && idx < len(r.Cells) && !r.Cells[idx].text {
+			ret = append(ret, r.Cells[idx].Value)
+		}
+	}
+	return ret
+}
+
+// ColumnStats builds the statistics of the column with the given name
+func (rt *Table) ColumnStats(name string) ColumnStats {
+	return NewColumnStats(rt.ColumnValues(rt.FindColumnIndex(name)))
+}
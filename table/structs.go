@@ -0,0 +1,120 @@
+package table
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func columnType(t reflect.Type) ColumnType {
+	if t == timeType {
+		return TimeColumn
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return IntColumn
+	case reflect.Float32, reflect.Float64:
+		return FloatColumn
+	case reflect.Bool:
+		return BoolColumn
+	}
+	return TextColumn
+}
+
+// structColumn reads a column definition from a field with an optional
+// tag like `table:"Change,format=percent(precision=1),align=right"`
+func structColumn(f reflect.StructField) (TableHeader, bool, error) {
+	h := Column(f.Name, columnType(f.Type))
+	tag, ok := f.Tag.Lookup("table")
+	if !ok {
+		return h, true, nil
+	}
+	if tag == "-" {
+		return h, false, nil
+	}
+	parts := splitTopLevel(tag, ',')
+	if parts[0] != "" {
+		h.Text = parts[0]
+	}
+	for _, p := range parts[1:] {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			return h, false, fmt.Errorf("field %s: invalid option %q", f.Name, p)
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "format":
+			fn, err := LookupFormatter(kv[1])
+			if err != nil {
+				return h, false, fmt.Errorf("field %s: %v", f.Name, err)
+			}
+			h.Format = fn
+		case "align":
+			switch strings.TrimSpace(kv[1]) {
+			case "left":
+				h.Align = AlignLeft
+			case "right":
+				h.Align = AlignRight
+			case "center":
+				h.Align = AlignCenter
+			}
+		case "null":
+			h.Null = kv[1]
+		default:
+			return h, false, fmt.Errorf("field %s: unknown option %q", f.Name, kv[0])
+		}
+	}
+	return h, true, nil
+}
+
+// FromStructs creates a table from a slice of structs. Every exported
+// field becomes a column unless it is tagged with `table:"-"`.
+func FromStructs(items interface{}) (*Table, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice but got %s", v.Kind())
+	}
+	et := v.Type().Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a slice of structs but got %s", et.Kind())
+	}
+	ret := New()
+	fields := make([]int, 0)
+	for i := 0; i < et.NumField(); i++ {
+		f := et.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		h, ok, err := structColumn(f)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ret.MarkedHeaders(h)
+			fields = append(fields, i)
+		}
+	}
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		if item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				continue
+			}
+			item = item.Elem()
+		}
+		values := make([]interface{}, len(fields))
+		for j, f := range fields {
+			values[j] = item.Field(f).Interface()
+		}
+		if err := ret.CreateRow().AddValues(values...); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
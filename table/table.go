@@ -5,10 +5,8 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"sort"
 	"strings"
 	"time"
-	"unicode/utf8"
 
 	"github.com/amecky/table/term"
 )
@@ -72,28 +70,45 @@ const (
 )
 
 type TableHeader struct {
-	Text   string
-	Marker int
+	Text     string
+	Marker   int
+	Rules    []Rule
+	Scale    *ColorScale
+	Type     ColumnType
+	Align    TextAlign
+	Format   FormatterFn
+	MinWidth int
+	MaxWidth int
+	Null     string
+	Expr     *Expression
+	Hidden   bool
 }
 
 type Table struct {
-	Description  string
-	Created      string
-	TableHeaders []TableHeader
-	Rows         []Row
-	Count        int
-	HeaderSizes  []int
-	Limit        int
-	Formatters   Formatters
-	BorderStyle  Border
-	PaddingSize  int
-	cr           *ConsoleRenderer
+	Description   string
+	Created       string
+	TableHeaders  []TableHeader
+	Rows          []*Row
+	HeaderSizes   []int
+	Limit         int
+	Formatters    Formatters
+	BorderStyle   Border
+	PaddingSize   int
+	NumberFormat  NumberFormat
+	TimeLayout    string
+	Location      *time.Location
+	cr            *ConsoleRenderer
+	extendHeaders bool
+	profile       term.Profile
+	keys          map[string]*Row
 }
 
 type Row struct {
+	Key         string
 	Size        int
 	Cells       []Cell
 	Highlighted bool
+	table       *Table
 }
 
 type Cell struct {
@@ -102,6 +117,26 @@ type Cell struct {
 	Marker    int
 	Alignment TextAlign
 	Link      string
+	fg        string
+	bg        string
+	bar       *BarOptions
+	// series is set for cells whose column formatter is applied to the
+	// whole column when the table is rendered
+	series bool
+	// text is set for cells without a numeric value. They are left out
+	// of the column values and statistics.
+	text bool
+	// spark marks the lowest and the highest point of a sparkline
+	spark *sparkPoints
+	// relative cells describe their unix time relative to the time the
+	// table is rendered
+	relative bool
+}
+
+// HasValue reports if the cell has a numeric value. Text cells and empty
+// indicator values have none.
+func (c Cell) HasValue() bool {
+	return !c.text
 }
 
 type MarkedText struct {
@@ -150,12 +185,14 @@ func (m *MarkedTextList) Add(txt string, marker int) {
 */
 func New() *Table {
 	tbl := Table{
-		Count:       0,
-		Limit:       -1,
-		Created:     time.Now().Format("2006-01-02 15:04"),
-		BorderStyle: DefaultBorder,
-		PaddingSize: 1,
-		cr:          NewConsoleRenderer(),
+		Limit:        -1,
+		Created:      time.Now().Format("2006-01-02 15:04"),
+		BorderStyle:  DefaultBorder,
+		PaddingSize:  1,
+		NumberFormat: DefaultNumberFormat,
+		TimeLayout:   DefaultTimeLayout,
+		cr:           NewConsoleRenderer(),
+		profile:      term.DetectProfile(),
 	}
 	tbl.Formatters = DefaultFormatters()
 	return &tbl
@@ -165,6 +202,31 @@ func (rt *Table) AddStyle(fg, bg string, bold bool) int {
 	return rt.cr.AddStyle(term.NewStyle(fg, bg, bold))
 }
 
+// Markers returns the marker registry used by all renderers of the table
+func (rt *Table) Markers() *MarkerRegistry {
+	return rt.cr.Markers
+}
+
+// DefineMarker registers a new marker and returns its id
+func (rt *Table) DefineMarker(def MarkerDef) int {
+	return rt.cr.Markers.Register(def)
+}
+
+// derive creates an empty table sharing name, headers and styles
+func (rt *Table) derive() *Table {
+	ret := New().Name(rt.Description).MarkedHeaders(rt.TableHeaders...)
+	ret.BorderStyle = rt.BorderStyle
+	ret.PaddingSize = rt.PaddingSize
+	ret.Formatters = rt.Formatters
+	ret.NumberFormat = rt.NumberFormat
+	ret.TimeLayout = rt.TimeLayout
+	ret.Location = rt.Location
+	ret.cr.Styles = rt.cr.Styles
+	ret.cr.Markers = rt.cr.Markers
+	ret.profile = rt.profile
+	return ret
+}
+
 func (rt *Table) Name(name string) *Table {
 	rt.Description = name
 	return rt
@@ -203,12 +265,12 @@ func (rt *Table) Style(styles Styles) *Table {
 }
 
 func (rt *Table) TableHeader(idx int, name string) *Table {
-	rt.TableHeaders[idx].Text = name
+	rt.SetHeader(idx, name)
 	return rt
 }
 
 func (rt *Table) MarkHeader(idx, marker int) *Table {
-	rt.TableHeaders[idx].Marker = marker
+	rt.SetHeaderMarker(idx, marker)
 	return rt
 }
 
@@ -222,49 +284,41 @@ func (rt *Table) FindColumnIndex(name string) int {
 }
 
 func (rt *Table) SetText(row, col int, txt string) {
-	if row < len(rt.Rows) {
+	if row >= 0 && row < len(rt.Rows) {
 		tr := rt.Rows[row]
-		if col < len(tr.Cells) {
+		if col >= 0 && col < len(tr.Cells) {
 			tr.Cells[col].Text = txt
 		}
 	}
 }
 
 func (rt *Table) Sort(name string) {
-	idx := -1
-	for i, n := range rt.TableHeaders {
-		if n.Text == name {
-			idx = i
-		}
-	}
-	if idx != -1 {
-		sort.Slice(rt.Rows, func(i, j int) bool {
-			return rt.Rows[i].Cells[idx].Value > rt.Rows[j].Cells[idx].Value
-		})
-	}
+	rt.SortBy(name, false)
 }
 
 func (rt *Table) SortReverse(name string) {
-	idx := -1
-	for i, n := range rt.TableHeaders {
-		if n.Text == name {
-			idx = i
-		}
-	}
-	if idx != -1 {
-		sort.Slice(rt.Rows, func(i, j int) bool {
-			return rt.Rows[i].Cells[idx].Value < rt.Rows[j].Cells[idx].Value
-		})
-	}
+	rt.SortBy(name, true)
 }
 
 func (rt *Table) CreateRow() *Row {
-	r := Row{
-		Size: len(rt.TableHeaders),
+	r := &Row{
+		Size:  len(rt.TableHeaders),
+		table: rt,
 	}
 	rt.Rows = append(rt.Rows, r)
-	rt.Count++
-	return &rt.Rows[rt.Count-1]
+	return r
+}
+
+// Len returns the number of rows
+func (rt *Table) Len() int {
+	return len(rt.Rows)
+}
+
+// Count returns the number of rows.
+//
+// Deprecated: Count used to be a field. Use Len instead.
+func (rt *Table) Count() int {
+	return rt.Len()
 }
 
 func (rt *Table) DelimiterLine(txt string) *Row {
@@ -279,37 +333,44 @@ func (rt *Table) AddTableHeader(name string) {
 	rt.TableHeaders = append(rt.TableHeaders, TableHeader{Text: name, Marker: 0})
 }
 
+// AddColumn adds a column with the values formatted by fn. NaN values
+// are kept as text cells with the value 0 so they are left out of the
+// column values and statistics.
 func (rt *Table) AddColumn(name string, values []float64, fn FormatterFn) {
 	rt.TableHeaders = append(rt.TableHeaders, TableHeader{Text: name, Marker: 0})
 	if len(rt.Rows) == 0 {
 		for i := 0; i < len(values); i++ {
-			r := rt.CreateRow()
-			txt, mk, al := fn(values, i)
-			r.AddAlignedText(txt, mk, al)
+			rt.CreateRow().addColumnValue(values, i, fn)
 		}
 	} else {
-		for i := 0; i < len(rt.Rows); i++ {
-			r := &rt.Rows[i]
+		for i, r := range rt.Rows {
 			if i < len(values) {
-				txt, mk, al := fn(values, i)
-				r.AddAlignedText(txt, mk, al)
+				r.addColumnValue(values, i, fn)
 			}
 		}
 	}
 }
 
+func (tr *Row) addColumnValue(values []float64, index int, fn FormatterFn) {
+	txt, mk, al := fn(values, index)
+	if math.IsNaN(values[index]) {
+		tr.Cells = append(tr.Cells, Cell{Text: txt, Marker: mk, Alignment: TextAlign(al), text: true})
+		return
+	}
+	tr.AddAlignedValue(txt, values[index], mk, al)
+}
+
 func (rt *Table) AddIntColumn(name string, values []int) {
 	rt.TableHeaders = append(rt.TableHeaders, TableHeader{Text: name, Marker: 0})
 	if len(rt.Rows) == 0 {
 		for i := 0; i < len(values); i++ {
 			r := rt.CreateRow()
-			r.AddAlignedText(fmt.Sprintf("%d", values[i]), 0, int(AlignRight))
+			r.AddAlignedValue(fmt.Sprintf("%d", values[i]), float64(values[i]), 0, int(AlignRight))
 		}
 	} else {
-		for i := 0; i < len(rt.Rows); i++ {
-			r := &rt.Rows[i]
+		for i, r := range rt.Rows {
 			if i < len(values) {
-				r.AddAlignedText(fmt.Sprintf("%d", values[i]), 0, int(AlignRight))
+				r.AddAlignedValue(fmt.Sprintf("%d", values[i]), float64(values[i]), 0, int(AlignRight))
 			}
 		}
 	}
@@ -323,8 +384,7 @@ func (rt *Table) AddStringColumn(name string, values []string) {
 			r.AddDefaultText(s)
 		}
 	} else {
-		for i := 0; i < len(rt.Rows); i++ {
-			r := &rt.Rows[i]
+		for i, r := range rt.Rows {
 			if i < len(values) {
 				r.AddDefaultText(values[i])
 			}
@@ -340,8 +400,7 @@ func (rt *Table) AddFormattedStringColumn(name string, values []string, format f
 			r.AddDefaultText(format(s))
 		}
 	} else {
-		for i := 0; i < len(rt.Rows); i++ {
-			r := &rt.Rows[i]
+		for i, r := range rt.Rows {
 			if i < len(values) {
 				r.AddDefaultText(format(values[i]))
 			}
@@ -357,8 +416,7 @@ func (rt *Table) AddMarkedTextColumn(name string, values []MarkedText) {
 			r.AddCenteredText(s.Text, s.Marker)
 		}
 	} else {
-		for i := 0; i < len(rt.Rows); i++ {
-			r := &rt.Rows[i]
+		for i, r := range rt.Rows {
 			if i < len(values) {
 				r.AddCenteredText(values[i].Text, values[i].Marker)
 			}
@@ -405,6 +463,7 @@ func (tr *Row) AddLink(txt, url string) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      txt,
 		Marker:    0,
+		text:      true,
 		Alignment: AlignLeft,
 		Link:      url,
 	})
@@ -415,6 +474,7 @@ func (tr *Row) AddDefaultText(txt string) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      txt,
 		Marker:    0,
+		text:      true,
 		Alignment: AlignLeft,
 	})
 	return tr
@@ -424,33 +484,45 @@ func (tr *Row) AddEmpty() *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      "",
 		Marker:    0,
+		text:      true,
 		Alignment: AlignLeft,
 	})
 	return tr
 }
 
 func (tr *Row) AddDate(txt string) *Row {
-	tmp := txt
-	if strings.Contains(tmp, " ") {
-		tmp = tmp[0:strings.Index(tmp, " ")]
+	tmp, _ := splitDateTime(txt)
+	v := 0.0
+	t, ok := ParseTime(txt, tr.location())
+	if ok {
+		v = float64(t.Unix())
 	}
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      tmp,
 		Marker:    0,
 		Alignment: AlignRight,
+		Value:     v,
+		text:      !ok,
 	})
 	return tr
 }
 
 func (tr *Row) AddTime(txt string) *Row {
-	tmp := txt
-	if strings.Contains(tmp, " ") {
-		tmp = tmp[strings.Index(tmp, " ")+1:]
+	_, tmp := splitDateTime(txt)
+	if tmp == "" {
+		tmp = txt
+	}
+	v := 0.0
+	t, ok := ParseTime(txt, tr.location())
+	if ok {
+		v = float64(t.Unix())
 	}
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      tmp,
 		Marker:    0,
 		Alignment: AlignRight,
+		Value:     v,
+		text:      !ok,
 	})
 	return tr
 }
@@ -459,6 +531,7 @@ func (tr *Row) AddText(txt string, marker int) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      txt,
 		Marker:    marker,
+		text:      true,
 		Alignment: AlignLeft,
 	})
 	return tr
@@ -468,6 +541,17 @@ func (tr *Row) AddAlignedText(txt string, marker, alignment int) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      txt,
 		Marker:    marker,
+		text:      true,
+		Alignment: TextAlign(alignment),
+	})
+	return tr
+}
+
+func (tr *Row) AddAlignedValue(txt string, v float64, marker, alignment int) *Row {
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      txt,
+		Value:     v,
+		Marker:    marker,
 		Alignment: TextAlign(alignment),
 	})
 	return tr
@@ -477,6 +561,7 @@ func (tr *Row) AddTextRight(txt string, marker int) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      txt,
 		Marker:    marker,
+		text:      true,
 		Alignment: AlignRight,
 	})
 	return tr
@@ -486,6 +571,7 @@ func (tr *Row) AddCenteredText(txt string, marker int) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      txt,
 		Marker:    marker,
+		text:      true,
 		Alignment: AlignCenter,
 	})
 	return tr
@@ -499,6 +585,7 @@ func (tr *Row) AddBlock(positive bool) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      "■",
 		Marker:    marker,
+		text:      true,
 		Alignment: AlignCenter,
 	})
 	return tr
@@ -541,6 +628,7 @@ func (tr *Row) AddHistoBlock(prev, cur float64) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      txt,
 		Marker:    marker,
+		text:      true,
 		Alignment: AlignCenter,
 	})
 	return tr
@@ -550,6 +638,7 @@ func (tr *Row) AddMarkedBlock(marker int) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      "■",
 		Marker:    marker,
+		text:      true,
 		Alignment: AlignCenter,
 	})
 	return tr
@@ -570,12 +659,17 @@ func (tr *Row) AddCategoryBlock(c float64) *Row {
 	tr.Cells = append(tr.Cells, Cell{
 		Text:      txt,
 		Marker:    marker,
+		text:      true,
 		Alignment: AlignLeft,
 	})
 	return tr
 }
 
 func (tr *Row) AddChangePercent(v float64) *Row {
+	return tr.AddChangePercentFormat(v, tr.numberFormat())
+}
+
+func (tr *Row) AddChangePercentFormat(v float64, nf NumberFormat) *Row {
 	marker := 0
 	vc := math.Round(v*100) / 100
 	if vc < 0.0 {
@@ -585,7 +679,7 @@ func (tr *Row) AddChangePercent(v float64) *Row {
 		marker = 1
 	}
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f%%", vc),
+		Text:      nf.Percent(vc),
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     vc,
@@ -609,8 +703,12 @@ func (tr *Row) AddCategorizedFloat(v, l1, l2, l3 float64) *Row {
 }
 
 func (tr *Row) AddFloat(v float64, marker int) *Row {
+	return tr.AddFloatFormat(v, marker, tr.numberFormat())
+}
+
+func (tr *Row) AddFloatFormat(v float64, marker int, nf NumberFormat) *Row {
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f", v),
+		Text:      nf.Format(v),
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     v,
@@ -634,7 +732,7 @@ func (tr *Row) AddFlaggedFloat(v float64, flag bool) *Row {
 		marker = -1
 	}
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f", v),
+		Text:      tr.numberFormat().Format(v),
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     v,
@@ -667,7 +765,7 @@ func (tr *Row) AddHisto(p, c float64) *Row {
 		}
 	}
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f", c),
+		Text:      tr.numberFormat().Format(c),
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     c,
@@ -710,7 +808,7 @@ func (tr *Row) AddColouredPercentage(v, steps float64) *Row {
 		s += steps
 	}
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f%%", v),
+		Text:      tr.numberFormat().Percent(v),
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     v,
@@ -731,7 +829,7 @@ func (tr *Row) AddMarkedPercentage(v float64, steps int) *Row {
 		s += si
 	}
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f%%", vp),
+		Text:      tr.numberFormat().Percent(vp),
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     v,
@@ -750,8 +848,9 @@ func (tr *Row) AddMarkedFloat(v float64) *Row {
 	} else {
 		rp = 0.0
 	}
-	txt := fmt.Sprintf("%.2f", rp)
-	if txt == "0.00" {
+	nf := tr.numberFormat()
+	txt := nf.Format(rp)
+	if txt == nf.Format(0.0) {
 		marker = 0
 	}
 	tr.Cells = append(tr.Cells, Cell{
@@ -789,7 +888,7 @@ func (tr *Row) AddMarkedFloatThreshold(v, t float64) *Row {
 		marker = 1
 	}
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f", v),
+		Text:      tr.numberFormat().Format(v),
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     v,
@@ -808,6 +907,10 @@ func (tr *Row) AddInt(v int, marker int) *Row {
 }
 
 func (tr *Row) AddChange(change, changePercent float64) *Row {
+	return tr.AddChangeFormat(change, changePercent, tr.numberFormat())
+}
+
+func (tr *Row) AddChangeFormat(change, changePercent float64, nf NumberFormat) *Row {
 	marker := 0
 	if changePercent > 0.0 {
 		marker = 1
@@ -815,7 +918,7 @@ func (tr *Row) AddChange(change, changePercent float64) *Row {
 		marker = -1
 	}
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f (%.2f%%)", change, changePercent),
+		Text:      nf.Format(change) + " (" + nf.Percent(changePercent) + ")",
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     change,
@@ -841,7 +944,7 @@ func (tr *Row) AddRelationPercentage(first, second float64) *Row {
 		marker = -1
 	}
 	tr.Cells = append(tr.Cells, Cell{
-		Text:      fmt.Sprintf("%.2f%%", changePercent),
+		Text:      tr.numberFormat().Percent(changePercent),
 		Marker:    marker,
 		Alignment: AlignRight,
 		Value:     changePercent,
@@ -858,7 +961,7 @@ func (tr *Row) AddRelation(first, second float64) *Row {
 func (rt *Table) RebuildSizes() {
 	for _, r := range rt.Rows {
 		for j, c := range r.Cells {
-			if internalLen(c.Text)+2 > rt.HeaderSizes[j] {
+			if j < len(rt.HeaderSizes) && internalLen(c.Text)+2 > rt.HeaderSizes[j] {
 				rt.HeaderSizes[j] = internalLen(c.Text) + 2
 			}
 		}
@@ -866,63 +969,90 @@ func (rt *Table) RebuildSizes() {
 }
 
 func (tr *Table) Sub(start, end int) *Table {
-	ret := New().Name(tr.Description).MarkedHeaders(tr.TableHeaders...)
+	ret := tr.derive()
 	if end > len(tr.Rows) {
 		end = len(tr.Rows)
 	}
 	for i := start; i < end; i++ {
-		ret.Rows = append(ret.Rows, tr.Rows[i])
+		ret.appendCopy(tr.Rows[i])
 	}
-	return ret
+	return ret.Recompute()
 }
 
 func (tr *Table) Filter(f string) *Table {
 	def := BuildFilterDef(f)
-	ret := New().Name(tr.Description).MarkedHeaders(tr.TableHeaders...)
+	ret := tr.derive()
 	rc := tr.FindColumnIndex(def.Header)
 	if rc != -1 {
+		t, isTime := ParseTime(def.Value, tr.Location)
+		// a date without time matches every timestamp of that day
+		_, err := time.Parse("2006-01-02", strings.TrimSpace(def.Value))
+		dateOnly := err == nil
+		loc := t.Location()
 		for _, r := range tr.Rows {
 			add := 0
+			if rc >= len(r.Cells) {
+				continue
+			}
 			n := r.Cells[rc].Text
-			if def.Comparator == 1 && n == def.Value {
+			cmp := strings.Compare(n, def.Value)
+			if isTime && r.Cells[rc].Value != 0.0 {
+				v := r.Cells[rc].Value
+				if dateOnly {
+					y, m, d := time.Unix(int64(v), 0).In(loc).Date()
+					v = float64(time.Date(y, m, d, 0, 0, 0, 0, loc).Unix())
+				}
+				cmp = compareFloat(v, float64(t.Unix()))
+			}
+			if def.Comparator == 1 && cmp == 0 {
 				add = 1
 			}
-			if def.Comparator == 2 && n != def.Value {
+			if def.Comparator == 2 && cmp != 0 {
 				add = 1
 			}
-			if def.Comparator == 3 && n >= def.Value {
+			if def.Comparator == 3 && cmp >= 0 {
 				add = 1
 			}
-			if def.Comparator == 4 && n <= def.Value {
+			if def.Comparator == 4 && cmp <= 0 {
 				add = 1
 			}
-			if def.Comparator == 5 && n > def.Value {
+			if def.Comparator == 5 && cmp > 0 {
 				add = 1
 			}
-			if def.Comparator == 6 && n < def.Value {
+			if def.Comparator == 6 && cmp < 0 {
 				add = 1
 			}
 			if add == 1 {
-				ret.Rows = append(ret.Rows, r)
+				ret.appendCopy(r)
 			}
 		}
 	}
-	return ret
+	return ret.Recompute()
+}
+
+func compareFloat(a, b float64) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
 }
 
 func (tr *Table) FilterRecent(num int) *Table {
 	if num == -1 {
 		return tr
 	}
-	ret := New().Name(tr.Description).MarkedHeaders(tr.TableHeaders...)
+	ret := tr.derive()
 	start := len(tr.Rows) - num
 	if start < 0 {
 		start = 0
 	}
 	for i := start; i < len(tr.Rows); i++ {
-		ret.Rows = append(ret.Rows, tr.Rows[i])
+		ret.appendCopy(tr.Rows[i])
 	}
-	return ret
+	return ret.Recompute()
 }
 
 func (tr *Table) Top(num int) *Table {
@@ -932,23 +1062,26 @@ func (tr *Table) Top(num int) *Table {
 	if num > len(tr.Rows) {
 		num = len(tr.Rows)
 	}
-	ret := New().Name(tr.Description).MarkedHeaders(tr.TableHeaders...)
+	ret := tr.derive()
 	for i := 0; i < num; i++ {
-		ret.Rows = append(ret.Rows, tr.Rows[i])
+		ret.appendCopy(tr.Rows[i])
 	}
-	return ret
+	return ret.Recompute()
 }
 
+// internalLen returns the number of columns used by the text. Wide
+// characters like CJK or emoji count as two columns.
 func internalLen(txt string) int {
-	return utf8.RuneCountInString(txt)
+	return term.StringWidth(txt)
 }
 
 func (rt *Table) Width() int {
 	ret := 0
-	for _, th := range rt.TableHeaders {
+	headers, rows := visible(rt.TableHeaders, rt.Rows)
+	for _, th := range headers {
 		ret += internalLen(th.Text) + rt.PaddingSize*2
 	}
-	for _, r := range rt.Rows {
+	for _, r := range rows {
 		cr := 0
 		for _, c := range r.Cells {
 			cr += internalLen(c.Text) + rt.PaddingSize*2
@@ -957,23 +1090,64 @@ func (rt *Table) Width() int {
 			ret = cr
 		}
 	}
-	ret += len(rt.TableHeaders) + 2
+	ret += len(headers) + 2
 	return ret
 }
 
 func (rt *Table) String() string {
+	return rt.render(false)
+}
+
+// ConvertTable draws the table with its colors at the position of the
+// canvas. It returns the width and the height of the table.
+func ConvertTable(c *term.Canvas, x, y int, rt *Table) (int, int) {
+	return c.Draw(x, y, rt)
+}
+
+// Plain renders the table without any escape sequences. Marked cells
+// are followed by the symbol of their marker.
+func (rt *Table) Plain() string {
+	return rt.render(true)
+}
+
+func (rt *Table) cellText(c Cell) string {
+	if rt.cr.Plain {
+		if sym := rt.cr.Markers.Get(c.Marker).Symbol; sym != "" {
+			return c.Text + " " + sym
+		}
+	}
+	return c.Text
+}
+
+func (rt *Table) render(plain bool) string {
+	rt.cr.Reset()
+	rt.cr.Plain = plain
+	rows := rt.view(!plain && rt.profile == term.TrueColor)
+	headers := rt.TableHeaders
+	if rt.extendHeaders {
+		headers = extendHeaders(headers, rows)
+	}
+	headers, rows = visible(headers, rows)
 	var sizes = make([]int, 0)
-	for _, th := range rt.TableHeaders {
+	for _, th := range headers {
 		sizes = append(sizes, internalLen(th.Text))
 	}
 	total := 0
-	for _, r := range rt.Rows {
+	for _, r := range rows {
 		for j, c := range r.Cells {
-			if internalLen(c.Text) > sizes[j] {
-				sizes[j] = internalLen(c.Text)
+			if j < len(sizes) && internalLen(rt.cellText(c)) > sizes[j] {
+				sizes[j] = internalLen(rt.cellText(c))
 			}
 		}
 	}
+	for j, th := range headers {
+		if th.MinWidth > 0 && sizes[j] < th.MinWidth {
+			sizes[j] = th.MinWidth
+		}
+		if th.MaxWidth > 0 && sizes[j] > th.MaxWidth {
+			sizes[j] = th.MaxWidth
+		}
+	}
 	for _, s := range sizes {
 		total += s + rt.PaddingSize*2
 	}
@@ -996,13 +1170,13 @@ func (rt *Table) String() string {
 	}
 
 	// headers
-	for j, h := range rt.TableHeaders {
+	for j, h := range headers {
 		if rt.BorderStyle.Size > 0 {
 			rt.cr.Append(rt.BorderStyle.H_LINE, rt.cr.Styles.Header)
 		}
 		hst := rt.cr.HeaderMarker(h.Marker)
 		rt.cr.Append(strings.Repeat(" ", rt.PaddingSize), hst)
-		rt.cr.Append(FormatString(h.Text, sizes[j], AlignCenter), hst)
+		rt.cr.Append(FormatString(truncate(h.Text, sizes[j]), sizes[j], AlignCenter), hst)
 		rt.cr.Append(strings.Repeat(" ", rt.PaddingSize), hst)
 	}
 	if rt.BorderStyle.Size > 0 {
@@ -1026,7 +1200,7 @@ func (rt *Table) String() string {
 		rt.cr.Append("\n", rt.cr.Styles.Text)
 	}
 
-	for j, r := range rt.Rows {
+	for j, r := range rows {
 		if rt.Limit == -1 || j < rt.Limit {
 			even := j % 2
 			bst := rt.cr.Styles.Header
@@ -1036,19 +1210,40 @@ func (rt *Table) String() string {
 			if r.Highlighted {
 				bst = bst.Background(term.BACKGROUND_HIGHLIGHTED)
 			}
-			for i, c := range r.Cells {
+			for i := range sizes {
+				c := Cell{}
+				if i < len(r.Cells) {
+					c = r.Cells[i]
+				}
 				if rt.BorderStyle.Size > 0 {
 					rt.cr.Append(rt.BorderStyle.H_LINE, bst)
 				}
 				st := rt.cr.Marker(c.Marker, j%2 == 0)
+				if c.fg != "" {
+					st = st.Foreground(c.fg)
+				}
+				if c.bg != "" {
+					st = st.Background(c.bg)
+				}
 				if r.Highlighted {
 					st = st.Background(term.BACKGROUND_HIGHLIGHTED)
 				}
 
 				rt.cr.Append(strings.Repeat(" ", rt.PaddingSize), st)
 
-				str := FormatString(c.Text, sizes[i], c.Alignment)
-				rt.cr.Append(str, st)
+				str := FormatString(truncate(rt.cellText(c), sizes[i]), sizes[i], c.Alignment)
+				if c.spark != nil {
+					highlighted := r.Highlighted
+					rt.appendSparkline(str, c, st, func(mk int) term.Style {
+						ms := rt.cr.Marker(mk, j%2 == 0)
+						if highlighted {
+							ms = ms.Background(term.BACKGROUND_HIGHLIGHTED)
+						}
+						return ms
+					})
+				} else {
+					rt.cr.Append(str, st)
+				}
 
 				rt.cr.Append(strings.Repeat(" ", rt.PaddingSize), st)
 			}
@@ -1084,7 +1279,7 @@ type TableRow struct {
 
 func (rt *Table) JSON(w io.Writer) error {
 	var rows = make([]TableRow, 0)
-	for j, r := range rt.Rows {
+	for j, r := range rt.view(false) {
 		if rt.Limit == -1 || j < rt.Limit {
 			tr := TableRow{}
 			for _, c := range r.Cells {
@@ -1096,8 +1291,12 @@ func (rt *Table) JSON(w io.Writer) error {
 			rows = append(rows, tr)
 		}
 	}
+	headers := make([]string, len(rt.TableHeaders))
+	for i, h := range rt.TableHeaders {
+		headers[i] = h.Text
+	}
 	reply := map[string]interface{}{
-		"headers": rt.Headers,
+		"headers": headers,
 		"rows":    rows,
 	}
 
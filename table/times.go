@@ -0,0 +1,126 @@
+package table
+
+import (
+	"strings"
+	"time"
+)
+
+const DefaultTimeLayout = "2006-01-02 15:04"
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime tries the common ISO-8601 layouts. Times without zone
+// are read in the given location.
+func ParseTime(txt string, loc *time.Location) (time.Time, bool) {
+	if loc == nil {
+		loc = time.Local
+	}
+	txt = strings.TrimSpace(txt)
+	for _, l := range timeLayouts {
+		if t, err := time.ParseInLocation(l, txt, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// splitDateTime splits a timestamp at the first space. The ISO-8601 'T'
+// is only used if it directly follows a date like 2006-01-02.
+func splitDateTime(txt string) (string, string) {
+	if len(txt) > 10 && txt[10] == 'T' {
+		if _, err := time.Parse("2006-01-02", txt[:10]); err == nil {
+			return txt[:10], txt[11:]
+		}
+	}
+	idx := strings.Index(txt, " ")
+	if idx == -1 {
+		return txt, ""
+	}
+	return txt[:idx], txt[idx+1:]
+}
+
+// Times sets the default layout and location of timestamps. A nil
+// location keeps the location of the timestamps.
+func (rt *Table) Times(layout string, loc *time.Location) *Table {
+	rt.TimeLayout = layout
+	rt.Location = loc
+	return rt
+}
+
+func (rt *Table) formatTime(t time.Time, layout string) string {
+	if layout == "" {
+		layout = rt.TimeLayout
+	}
+	if layout == "" {
+		layout = DefaultTimeLayout
+	}
+	if rt.Location != nil {
+		t = t.In(rt.Location)
+	}
+	return t.Format(layout)
+}
+
+func (tr *Row) formatTime(t time.Time, layout string) string {
+	if tr.table != nil {
+		return tr.table.formatTime(t, layout)
+	}
+	if layout == "" {
+		layout = DefaultTimeLayout
+	}
+	return t.Format(layout)
+}
+
+func (tr *Row) timeFormat(t time.Time) string {
+	return tr.formatTime(t, "")
+}
+
+func (tr *Row) location() *time.Location {
+	if tr.table != nil {
+		return tr.table.Location
+	}
+	return nil
+}
+
+// AddTimestamp adds the time converted to the location of the table. An
+// empty layout uses the default layout of the table. The value is the unix time.
+func (tr *Row) AddTimestamp(t time.Time, layout string) *Row {
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      tr.formatTime(t, layout),
+		Marker:    0,
+		Alignment: AlignRight,
+		Value:     float64(t.Unix()),
+	})
+	return tr
+}
+
+// FilterDateRange returns all rows where the time of the column is
+// within [from, to). A zero time leaves that side of the range open.
+func (tr *Table) FilterDateRange(column string, from, to time.Time) *Table {
+	ret := tr.derive()
+	rc := tr.FindColumnIndex(column)
+	if rc == -1 {
+		return ret
+	}
+	for _, r := range tr.Rows {
+		if rc >= len(r.Cells) {
+			continue
+		}
+		v := int64(r.Cells[rc].Value)
+		if !from.IsZero() && v < from.Unix() {
+			continue
+		}
+		if !to.IsZero() && v >= to.Unix() {
+			continue
+		}
+		ret.appendCopy(r)
+	}
+	return ret.Recompute()
+}
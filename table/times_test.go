@@ -0,0 +1,74 @@
+package table
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitDateTime(t *testing.T) {
+	tests := []struct {
+		txt  string
+		date string
+		time string
+	}{
+		{"2024-03-01 10:15", "2024-03-01", "10:15"},
+		{"2024-03-01T10:15:00Z", "2024-03-01", "10:15:00Z"},
+		{"2024-03-01", "2024-03-01", ""},
+		{"Tuesday 10:00", "Tuesday", "10:00"},
+		{"TBD", "TBD", ""},
+		{"Total Time", "Total", "Time"},
+	}
+	for _, tc := range tests {
+		d, tm := splitDateTime(tc.txt)
+		if d != tc.date || tm != tc.time {
+			t.Errorf("%q: expected %q %q but got %q %q", tc.txt, tc.date, tc.time, d, tm)
+		}
+	}
+}
+
+func TestAddDateAndTime(t *testing.T) {
+	tbl := New().Headers("Date", "Time")
+	tbl.CreateRow().AddDate("Tuesday 10:00").AddTime("TBD")
+	tbl.CreateRow().AddDate("2024-03-01T10:15").AddTime("2024-03-01T10:15")
+	if c := tbl.Rows[0].Cells; c[0].Text != "Tuesday" || c[1].Text != "TBD" {
+		t.Errorf("unexpected texts %q %q", c[0].Text, c[1].Text)
+	}
+	c := tbl.Rows[1].Cells
+	if c[0].Text != "2024-03-01" || c[1].Text != "10:15" {
+		t.Errorf("unexpected texts %q %q", c[0].Text, c[1].Text)
+	}
+	expected := time.Date(2024, 3, 1, 10, 15, 0, 0, time.Local).Unix()
+	if int64(c[0].Value) != expected {
+		t.Errorf("expected value %d but got %d", expected, int64(c[0].Value))
+	}
+}
+
+func TestFilterTime(t *testing.T) {
+	tbl := New().Headers("Date")
+	for _, d := range []string{"2024-03-01", "2024-03-05", "2024-03-10"} {
+		tbl.CreateRow().AddDate(d)
+	}
+	if n := tbl.Filter("Date >= 2024-03-05").Len(); n != 2 {
+		t.Errorf("expected 2 rows but got %d", n)
+	}
+	timestamps := New().Headers("Date").Times("", time.UTC)
+	for _, d := range []string{"2024-01-04 23:30", "2024-01-05 10:00", "2024-01-05 23:59", "2024-01-06 00:00"} {
+		timestamps.CreateRow().AddDate(d)
+	}
+	tests := []struct {
+		filter   string
+		expected int
+	}{
+		{"Date == 2024-01-05", 2},
+		{"Date <= 2024-01-05", 3},
+		{"Date != 2024-01-05", 2},
+		{"Date > 2024-01-05", 1},
+		{"Date == 2024-01-05T10:00", 1},
+		{"Date < 2024-01-05T10:00", 1},
+	}
+	for _, tc := range tests {
+		if n := timestamps.Filter(tc.filter).Len(); n != tc.expected {
+			t.Errorf("%s: expected %d rows but got %d", tc.filter, tc.expected, n)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var siSuffixes = []string{"", "k", "M", "G", "T", "P", "E"}
+
+var iecSuffixes = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+func trimZeros(txt string) string {
+	if strings.Contains(txt, ".") {
+		txt = strings.TrimRight(txt, "0")
+		txt = strings.TrimSuffix(txt, ".")
+	}
+	return txt
+}
+
+// CompactNumber shortens the number using SI suffixes like 1.2M or 3.4k
+func CompactNumber(v float64, precision int) string {
+	a := math.Abs(v)
+	idx := 0
+	for a >= 999.5 && idx < len(siSuffixes)-1 {
+		a /= 1000.0
+		idx++
+	}
+	// rounding might reach the next unit
+	if math.Round(a*math.Pow(10, float64(precision))) >= 1000.0*math.Pow(10, float64(precision)) && idx < len(siSuffixes)-1 {
+		a /= 1000.0
+		idx++
+	}
+	txt := trimZeros(fmt.Sprintf("%.*f", precision, a)) + siSuffixes[idx]
+	if v < 0.0 && txt != "0" {
+		txt = "-" + txt
+	}
+	return txt
+}
+
+// IECBytes formats the number of bytes using binary suffixes like 1.5 GiB
+func IECBytes(v float64) string {
+	a := math.Abs(v)
+	idx := 0
+	// bytes are shown without and the larger units with one decimal, so
+	// rounding might reach the next unit like 1023.96 KiB
+	rounded := func(a float64, idx int) float64 {
+		if idx == 0 {
+			return math.Round(a)
+		}
+		return math.Round(a*10.0) / 10.0
+	}
+	for rounded(a, idx) >= 1024.0 && idx < len(iecSuffixes)-1 {
+		a /= 1024.0
+		idx++
+	}
+	txt := fmt.Sprintf("%.0f %s", a, iecSuffixes[idx])
+	if idx > 0 {
+		txt = trimZeros(fmt.Sprintf("%.1f", a)) + " " + iecSuffixes[idx]
+	}
+	if v < 0.0 {
+		txt = "-" + txt
+	}
+	return txt
+}
+
+// HumanDuration formats the duration using the two most significant units like 1h02m
+func HumanDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	switch {
+	case d < time.Microsecond:
+		return fmt.Sprintf("%s%dns", sign, d.Nanoseconds())
+	case d < time.Millisecond:
+		return fmt.Sprintf("%s%dµs", sign, d.Microseconds())
+	case d < time.Second:
+		return fmt.Sprintf("%s%dms", sign, d.Milliseconds())
+	case d < time.Minute:
+		return sign + trimZeros(fmt.Sprintf("%.1f", d.Seconds())) + "s"
+	case d < time.Hour:
+		return fmt.Sprintf("%s%dm%02ds", sign, int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%s%dh%02dm", sign, int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%s%dd%02dh", sign, int(d.Hours())/24, int(d.Hours())%24)
+}
+
+// RelativeTime describes the time relative to now like "3m ago" or "in 2h"
+func RelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	txt := ""
+	switch {
+	case d < 10*time.Second:
+		return "just now"
+	case d < time.Minute:
+		txt = fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		txt = fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		txt = fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		txt = fmt.Sprintf("%dd", int(d.Hours())/24)
+	case d < 365*24*time.Hour:
+		txt = fmt.Sprintf("%dmo", int(d.Hours())/24/30)
+	default:
+		txt = fmt.Sprintf("%dy", int(d.Hours())/24/365)
+	}
+	if future {
+		return "in " + txt
+	}
+	return txt + " ago"
+}
+
+// AddCompact adds a number like 1.2M keeping the raw value
+func (tr *Row) AddCompact(v float64, marker int) *Row {
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      CompactNumber(v, 1),
+		Marker:    marker,
+		Alignment: AlignRight,
+		Value:     v,
+	})
+	return tr
+}
+
+// AddBytes adds a size like 1.5 GiB keeping the number of bytes as value
+func (tr *Row) AddBytes(v float64) *Row {
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      IECBytes(v),
+		Marker:    0,
+		Alignment: AlignRight,
+		Value:     v,
+	})
+	return tr
+}
+
+// AddDuration adds a duration like 1h02m. The value is the duration in seconds
+func (tr *Row) AddDuration(d time.Duration) *Row {
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      HumanDuration(d),
+		Marker:    0,
+		Alignment: AlignRight,
+		Value:     d.Seconds(),
+	})
+	return tr
+}
+
+// AddRelativeTime adds a time like "3m ago". The value is the unix time
+// and the text is updated whenever the table is rendered.
+func (tr *Row) AddRelativeTime(t time.Time) *Row {
+	tr.Cells = append(tr.Cells, Cell{
+		Text:      RelativeTime(t, time.Now()),
+		Marker:    0,
+		Alignment: AlignRight,
+		Value:     float64(t.Unix()),
+		relative:  true,
+	})
+	return tr
+}
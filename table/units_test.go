@@ -0,0 +1,93 @@
+package table
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompactNumber(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1200, "1.2k"},
+		{-1500000, "-1.5M"},
+		{999950, "1M"},
+	}
+	for _, tc := range tests {
+		if got := CompactNumber(tc.value, 1); got != tc.expected {
+			t.Errorf("%v: expected %q but got %q", tc.value, tc.expected, got)
+		}
+	}
+}
+
+func TestIECBytes(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1023.6, "1 KiB"},
+		{1024, "1 KiB"},
+		{1536, "1.5 KiB"},
+		{1023.96 * 1024, "1 MiB"},
+		{1023.94 * 1024, "1023.9 KiB"},
+		{-2.5 * 1024 * 1024 * 1024, "-2.5 GiB"},
+	}
+	for _, tc := range tests {
+		if got := IECBytes(tc.value); got != tc.expected {
+			t.Errorf("%v: expected %q but got %q", tc.value, tc.expected, got)
+		}
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		value    time.Duration
+		expected string
+	}{
+		{500 * time.Nanosecond, "500ns"},
+		{1500 * time.Millisecond, "1.5s"},
+		{62 * time.Minute, "1h02m"},
+		{-90 * time.Second, "-1m30s"},
+		{49 * time.Hour, "2d01h"},
+	}
+	for _, tc := range tests {
+		if got := HumanDuration(tc.value); got != tc.expected {
+			t.Errorf("%v: expected %q but got %q", tc.value, tc.expected, got)
+		}
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    time.Time
+		expected string
+	}{
+		{now.Add(-5 * time.Second), "just now"},
+		{now.Add(-3 * time.Minute), "3m ago"},
+		{now.Add(2 * time.Hour), "in 2h"},
+		{now.Add(-3 * 24 * time.Hour), "3d ago"},
+		{now.Add(-400 * 24 * time.Hour), "1y ago"},
+	}
+	for _, tc := range tests {
+		if got := RelativeTime(tc.value, now); got != tc.expected {
+			t.Errorf("%v: expected %q but got %q", tc.value, tc.expected, got)
+		}
+	}
+}
+
+func TestAddRelativeTimeUpdates(t *testing.T) {
+	tbl := New().Headers("Seen")
+	tbl.CreateRow().AddRelativeTime(time.Now().Add(-3 * time.Minute))
+	// the text is stale until the table is rendered
+	tbl.Rows[0].Cells[0].Text = "old"
+	rows := tbl.view(false)
+	if txt := rows[0].Cells[0].Text; txt != "3m ago" {
+		t.Errorf("expected the text to be updated but got %q", txt)
+	}
+}
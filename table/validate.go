@@ -0,0 +1,143 @@
+package table
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Validate checks that every row has exactly one cell per column
+func (rt *Table) Validate() error {
+	errs := make([]string, 0)
+	for i, r := range rt.Rows {
+		if len(r.Cells) != len(rt.TableHeaders) {
+			errs = append(errs, fmt.Sprintf("row %d has %d cells but table has %d columns", i, len(r.Cells), len(rt.TableHeaders)))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid table: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// ExtendHeaders defines if rows with more cells than headers add
+// unnamed columns. Otherwise the additional cells are ignored.
+func (rt *Table) ExtendHeaders(extend bool) *Table {
+	rt.extendHeaders = extend
+	return rt
+}
+
+func extendHeaders(headers []TableHeader, rows []*Row) []TableHeader {
+	max := len(headers)
+	for _, r := range rows {
+		if len(r.Cells) > max {
+			max = len(r.Cells)
+		}
+	}
+	if max == len(headers) {
+		return headers
+	}
+	ret := make([]TableHeader, max)
+	copy(ret, headers)
+	return ret
+}
+
+// Normalize pads short rows with empty cells. Rows with additional cells
+// either add headers or are cut depending on ExtendHeaders.
+func (rt *Table) Normalize() *Table {
+	if rt.extendHeaders {
+		rt.TableHeaders = extendHeaders(rt.TableHeaders, rt.Rows)
+	}
+	for _, r := range rt.Rows {
+		if len(r.Cells) > len(rt.TableHeaders) {
+			r.Cells = r.Cells[:len(rt.TableHeaders)]
+		}
+	}
+	rt.padRows()
+	return rt
+}
+
+// padRows adds empty cells to all rows with fewer cells than columns.
+// Surplus cells are kept.
+func (rt *Table) padRows() {
+	for _, r := range rt.Rows {
+		for len(r.Cells) < len(rt.TableHeaders) {
+			r.AddEmpty()
+		}
+	}
+}
+
+func (rt *Table) checkColumn(idx int) error {
+	if idx < 0 || idx >= len(rt.TableHeaders) {
+		return fmt.Errorf("column index %d out of range [0, %d)", idx, len(rt.TableHeaders))
+	}
+	return nil
+}
+
+func (rt *Table) checkRow(idx int) error {
+	if idx < 0 || idx >= len(rt.Rows) {
+		return fmt.Errorf("row index %d out of range [0, %d)", idx, len(rt.Rows))
+	}
+	return nil
+}
+
+// SetHeader renames the header at the given index
+func (rt *Table) SetHeader(idx int, name string) error {
+	if err := rt.checkColumn(idx); err != nil {
+		return err
+	}
+	rt.TableHeaders[idx].Text = name
+	return nil
+}
+
+// SetHeaderMarker sets the marker of the header at the given index
+func (rt *Table) SetHeaderMarker(idx, marker int) error {
+	if err := rt.checkColumn(idx); err != nil {
+		return err
+	}
+	rt.TableHeaders[idx].Marker = marker
+	return nil
+}
+
+func cellValue(r *Row, idx int) float64 {
+	if idx < len(r.Cells) {
+		return r.Cells[idx].Value
+	}
+	return 0.0
+}
+
+// sortValue is the value of the cell used for sorting. Text cells have
+// no value and are sorted like NaN.
+func sortValue(r *Row, idx int) float64 {
+	if idx < len(r.Cells) && r.Cells[idx].text {
+		return math.NaN()
+	}
+	return cellValue(r, idx)
+}
+
+// sortsBefore reports if the value a comes before b. NaN values like the
+// warmup period of an indicator always come last.
+func sortsBefore(a, b float64, reverse bool) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return !math.IsNaN(a)
+	}
+	if reverse {
+		return a < b
+	}
+	return a > b
+}
+
+// SortBy sorts the rows by the values of the column. By default the
+// highest values come first. Rows without a value (NaN or text) are put last.
+func (rt *Table) SortBy(name string, reverse bool) error {
+	idx := rt.FindColumnIndex(name)
+	if idx == -1 {
+		return fmt.Errorf("unknown column %q", name)
+	}
+	sort.SliceStable(rt.Rows, func(i, j int) bool {
+		return sortsBefore(sortValue(rt.Rows[i], idx), sortValue(rt.Rows[j], idx), reverse)
+	})
+	rt.Recompute()
+	return nil
+}
@@ -0,0 +1,176 @@
+package table
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/amecky/table/term"
+)
+
+func raggedTable() *Table {
+	t := New().Headers("A", "B")
+	t.CreateRow().AddDefaultText("x")
+	t.CreateRow().AddDefaultText("1").AddDefaultText("2").AddDefaultText("3")
+	return t
+}
+
+func TestRaggedRowsString(t *testing.T) {
+	tbl := raggedTable()
+	expected := strings.Join([]string{
+		"┌───┬───┐",
+		"│ A │ B │",
+		"├───┼───┤",
+		"│ x │   │",
+		"│ 1 │ 2 │",
+		"└───┴───┘",
+	}, "\n")
+	if got := tbl.Plain(); got != expected {
+		t.Errorf("unexpected output\n%s\nexpected\n%s", got, expected)
+	}
+	if tbl.String() == "" {
+		t.Error("String returned an empty text")
+	}
+	if len(tbl.Rows[0].Cells) != 1 || len(tbl.Rows[1].Cells) != 3 {
+		t.Error("rendering must not change the rows")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tbl := raggedTable()
+	err := tbl.Validate()
+	if err == nil {
+		t.Fatal("expected an error for ragged rows")
+	}
+	if !strings.Contains(err.Error(), "row 0 has 1 cells") || !strings.Contains(err.Error(), "row 1 has 3 cells") {
+		t.Errorf("unexpected error %v", err)
+	}
+	tbl.Normalize()
+	if err := tbl.Validate(); err != nil {
+		t.Errorf("unexpected error after Normalize: %v", err)
+	}
+}
+
+func TestNormalizePadsShortRows(t *testing.T) {
+	tbl := raggedTable().Normalize()
+	r := tbl.Rows[0]
+	if len(r.Cells) != 2 {
+		t.Fatalf("expected 2 cells but got %d", len(r.Cells))
+	}
+	if r.Cells[0].Text != "x" || r.Cells[1].Text != "" {
+		t.Errorf("unexpected cells %q %q", r.Cells[0].Text, r.Cells[1].Text)
+	}
+	if len(tbl.Rows[1].Cells) != 2 {
+		t.Errorf("expected the surplus cell to be cut but got %d cells", len(tbl.Rows[1].Cells))
+	}
+}
+
+func TestExtendHeaders(t *testing.T) {
+	tbl := raggedTable().ExtendHeaders(true)
+	expected := strings.Join([]string{
+		"┌───┬───┬───┐",
+		"│ A │ B │   │",
+		"├───┼───┼───┤",
+		"│ x │   │   │",
+		"│ 1 │ 2 │ 3 │",
+		"└───┴───┴───┘",
+	}, "\n")
+	if got := tbl.Plain(); got != expected {
+		t.Errorf("unexpected output\n%s\nexpected\n%s", got, expected)
+	}
+	if len(tbl.TableHeaders) != 2 {
+		t.Errorf("rendering must not add headers but got %d", len(tbl.TableHeaders))
+	}
+	tbl.Normalize()
+	if len(tbl.TableHeaders) != 3 {
+		t.Fatalf("expected 3 headers after Normalize but got %d", len(tbl.TableHeaders))
+	}
+	if len(tbl.Rows[0].Cells) != 3 || tbl.Rows[1].Cells[2].Text != "3" {
+		t.Error("expected all rows to have 3 cells keeping the surplus cell")
+	}
+}
+
+func TestSetHeaderOutOfRange(t *testing.T) {
+	tbl := New().Headers("A", "B")
+	for _, idx := range []int{-1, 2, 10} {
+		if err := tbl.SetHeader(idx, "C"); err == nil {
+			t.Errorf("expected an error for index %d", idx)
+		}
+		if err := tbl.SetHeaderMarker(idx, MarkerPositive); err == nil {
+			t.Errorf("expected an error for index %d", idx)
+		}
+	}
+	if err := tbl.SetHeader(1, "C"); err != nil {
+		t.Fatal(err)
+	}
+	if tbl.TableHeaders[1].Text != "C" {
+		t.Errorf("expected header C but got %q", tbl.TableHeaders[1].Text)
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	tbl := New().Headers("Name", "Value")
+	for i, v := range []int{2, 3, 1} {
+		tbl.CreateRow().AddDefaultText(string(rune('a'+i))).AddInt(v, 0)
+	}
+	if err := tbl.SortBy("Unknown", false); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+	if err := tbl.SortBy("Value", false); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(tbl); got != "bac" {
+		t.Errorf("expected bac but got %s", got)
+	}
+	if err := tbl.SortBy("Value", true); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(tbl); got != "cab" {
+		t.Errorf("expected cab but got %s", got)
+	}
+}
+
+func names(tbl *Table) string {
+	ret := ""
+	for _, r := range tbl.Rows {
+		ret += r.Cells[0].Text
+	}
+	return ret
+}
+
+func TestSortByPutsNaNLast(t *testing.T) {
+	tbl := New().Headers("Name", "Value")
+	for i, v := range []float64{math.NaN(), 2, math.NaN(), 3, 1} {
+		tbl.CreateRow().AddDefaultText(string(rune('a'+i))).AddFloat(v, 0)
+	}
+	for _, tc := range []struct {
+		reverse  bool
+		expected string
+	}{
+		{false, "dbeac"},
+		{true, "ebdac"},
+	} {
+		if err := tbl.SortBy("Value", tc.reverse); err != nil {
+			t.Fatal(err)
+		}
+		if got := names(tbl); got != tc.expected {
+			t.Errorf("reverse %v: expected %s but got %s", tc.reverse, tc.expected, got)
+		}
+	}
+}
+
+func TestWideCharactersAlign(t *testing.T) {
+	tbl := New().Headers("Name", "Value")
+	tbl.CreateRow().AddDefaultText("東京").AddDefaultText("1")
+	tbl.CreateRow().AddDefaultText("🚀").AddDefaultText("2")
+	tbl.CreateRow().AddDefaultText("abc").AddDefaultText("3")
+	lines := strings.Split(tbl.Plain(), "\n")
+	for _, l := range lines {
+		if w := term.StringWidth(l); w != term.StringWidth(lines[0]) {
+			t.Errorf("line %q is %d columns wide", l, w)
+		}
+	}
+	if got := truncate("東京都", 4); got != "東…" {
+		t.Errorf("expected 東… but got %q", got)
+	}
+}
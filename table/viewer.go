@@ -0,0 +1,456 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/amecky/table/term"
+)
+
+const (
+	viewerNormal = iota
+	viewerSearch
+	viewerFilter
+)
+
+// Viewer is an interactive term.Model showing a table. It works on a copy
+// of the table so sorting and filtering do not change the original.
+//
+// Keys: up/down or j/k move the selected row, pgup/pgdown and home/end
+// jump, left/right or h/l scroll horizontally, 1-9 sort by the visible
+// column (pressing it again reverses the order), 0 removes the sorting, /
+// searches, n/N jump to the next/previous match, f filters using the
+// syntax of Filter like "Close > 10" and q quits. Numbers are sorted with
+// the highest value first and texts in alphabetical order.
+type Viewer struct {
+	source  *Table
+	view    *Table
+	lines   []string
+	header  int
+	footer  int
+	width   int
+	height  int
+	top     int
+	left    int
+	cursor  int
+	sortCol int
+	reverse bool
+	mode    int
+	input   string
+	search  string
+	start   int
+	filter  string
+	status  string
+	dirty   bool
+}
+
+func NewViewer(t *Table) *Viewer {
+	v := &Viewer{
+		source:  t,
+		sortCol: -1,
+	}
+	v.apply()
+	return v
+}
+
+// Browse shows the table in the interactive viewer until q is pressed
+func (rt *Table) Browse(opts ...term.ProgramOption) error {
+	_, err := term.Run(NewViewer(rt), opts...)
+	return err
+}
+
+// Table returns the sorted and filtered table shown by the viewer
+func (v *Viewer) Table() *Table {
+	return v.view
+}
+
+// Selected returns the selected row or nil if the table is empty
+func (v *Viewer) Selected() *Row {
+	if v.cursor < len(v.view.Rows) {
+		return v.view.Rows[v.cursor]
+	}
+	return nil
+}
+
+// apply creates the view from the source using the current filter and sort order
+func (v *Viewer) apply() {
+	var selected *Row
+	if v.view != nil {
+		selected = v.Selected()
+	}
+	if v.filter != "" {
+		v.view = v.source.Filter(v.filter)
+	} else {
+		v.view = v.source.Sub(0, len(v.source.Rows))
+	}
+	if v.sortCol >= 0 && v.sortCol < len(v.view.TableHeaders) {
+		v.sortView()
+	}
+	for _, r := range v.view.Rows {
+		r.Highlighted = false
+	}
+	if selected != nil {
+		for i, r := range v.view.Rows {
+			if sameRow(r, selected) {
+				v.cursor = i
+				break
+			}
+		}
+	}
+	v.dirty = true
+	v.move(0)
+}
+
+// sortView sorts the view by the values of the column or by the texts
+// if the column contains only texts
+func (v *Viewer) sortView() {
+	idx := v.sortCol
+	for _, r := range v.view.Rows {
+		if cellValue(r, idx) != 0.0 {
+			v.view.SortBy(v.view.TableHeaders[idx].Text, v.reverse)
+			return
+		}
+	}
+	text := func(r *Row) string {
+		if idx < len(r.Cells) {
+			return strings.ToLower(r.Cells[idx].Text)
+		}
+		return ""
+	}
+	sort.SliceStable(v.view.Rows, func(i, j int) bool {
+		if v.reverse {
+			return text(v.view.Rows[i]) > text(v.view.Rows[j])
+		}
+		return text(v.view.Rows[i]) < text(v.view.Rows[j])
+	})
+}
+
+// Refresh shows the current rows of the source table. The sort order,
+// the filter, the search and the selected row are kept.
+func (v *Viewer) Refresh() {
+	v.apply()
+}
+
+// sameRow compares the keys of the rows or the texts if they have no key
+func sameRow(a, b *Row) bool {
+	if a.Key != "" || b.Key != "" {
+		return a.Key == b.Key
+	}
+	if len(a.Cells) != len(b.Cells) {
+		return false
+	}
+	for i, c := range a.Cells {
+		if c.Text != b.Cells[i].Text {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *Viewer) move(delta int) {
+	v.cursor += delta
+	if v.cursor >= len(v.view.Rows) {
+		v.cursor = len(v.view.Rows) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+func (v *Viewer) matches(r *Row, txt string) bool {
+	txt = strings.ToLower(txt)
+	for _, c := range r.Cells {
+		if strings.Contains(strings.ToLower(c.Text), txt) {
+			return true
+		}
+	}
+	return false
+}
+
+// find selects the next row matching the search starting at from
+func (v *Viewer) find(from, dir int) bool {
+	n := len(v.view.Rows)
+	if v.search == "" || n == 0 {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		idx := ((from+i*dir)%n + n) % n
+		if v.matches(v.view.Rows[idx], v.search) {
+			v.cursor = idx
+			return true
+		}
+	}
+	return false
+}
+
+// sortBy sorts by the column at the position counting only visible columns
+func (v *Viewer) sortBy(pos int) {
+	columns := visibleColumns(v.source.TableHeaders)
+	if pos >= len(columns) {
+		return
+	}
+	col := columns[pos]
+	if v.sortCol == col {
+		v.reverse = !v.reverse
+	} else {
+		v.sortCol = col
+		v.reverse = false
+	}
+	v.apply()
+}
+
+func (v *Viewer) pageSize() int {
+	if v.height <= 0 {
+		return 10
+	}
+	size := v.height - 2 - v.header - v.footer
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+func (v *Viewer) Init() term.Cmd {
+	return nil
+}
+
+func (v *Viewer) Update(msg term.Msg) (term.Model, term.Cmd) {
+	switch m := msg.(type) {
+	case term.WindowSizeMsg:
+		v.width = m.Width
+		v.height = m.Height
+	case term.KeyMsg:
+		if m.Key == term.KeyCtrl && m.Rune == 'c' {
+			return v, term.Quit
+		}
+		switch v.mode {
+		case viewerSearch, viewerFilter:
+			v.updateInput(m)
+		default:
+			return v.updateNormal(m)
+		}
+	}
+	return v, nil
+}
+
+func (v *Viewer) updateInput(m term.KeyMsg) {
+	switch m.Key {
+	case term.KeyEsc:
+		if v.mode == viewerSearch {
+			v.search = ""
+			v.cursor = v.start
+		}
+		v.mode = viewerNormal
+		return
+	case term.KeyEnter:
+		if v.mode == viewerFilter {
+			v.applyFilter(v.input)
+		}
+		v.mode = viewerNormal
+		return
+	case term.KeyBackspace:
+		if r := []rune(v.input); len(r) > 0 {
+			v.input = string(r[:len(r)-1])
+		}
+	case term.KeyRune:
+		v.input += string(m.Rune)
+	default:
+		return
+	}
+	if v.mode == viewerSearch {
+		v.search = v.input
+		if !v.find(v.start, 1) {
+			v.cursor = v.start
+		}
+	}
+}
+
+func (v *Viewer) applyFilter(f string) {
+	v.status = ""
+	if f != "" {
+		def := BuildFilterDef(f)
+		if def.Comparator == 0 || v.source.FindColumnIndex(def.Header) == -1 {
+			v.status = fmt.Sprintf("invalid filter %q", f)
+			return
+		}
+	}
+	v.filter = f
+	v.cursor = 0
+	v.top = 0
+	v.apply()
+}
+
+func (v *Viewer) updateNormal(m term.KeyMsg) (term.Model, term.Cmd) {
+	switch m.Key {
+	case term.KeyUp:
+		v.move(-1)
+	case term.KeyDown:
+		v.move(1)
+	case term.KeyPgUp:
+		v.move(-v.pageSize())
+	case term.KeyPgDown:
+		v.move(v.pageSize())
+	case term.KeyHome:
+		v.move(-len(v.view.Rows))
+	case term.KeyEnd:
+		v.move(len(v.view.Rows))
+	case term.KeyLeft:
+		v.scroll(-4)
+	case term.KeyRight:
+		v.scroll(4)
+	case term.KeyEsc:
+		v.search = ""
+		v.status = ""
+	case term.KeyRune:
+		switch r := m.Rune; {
+		case r == 'q':
+			return v, term.Quit
+		case r == 'k':
+			v.move(-1)
+		case r == 'j':
+			v.move(1)
+		case r == 'g':
+			v.move(-len(v.view.Rows))
+		case r == 'G':
+			v.move(len(v.view.Rows))
+		case r == 'h':
+			v.scroll(-4)
+		case r == 'l':
+			v.scroll(4)
+		case r >= '1' && r <= '9':
+			v.sortBy(int(r - '1'))
+		case r == '0':
+			v.sortCol = -1
+			v.reverse = false
+			v.apply()
+		case r == '/':
+			v.mode = viewerSearch
+			v.input = ""
+			v.start = v.cursor
+		case r == 'n':
+			v.find(v.cursor+1, 1)
+		case r == 'N':
+			v.find(v.cursor-1, -1)
+		case r == 'f':
+			v.mode = viewerFilter
+			v.input = v.filter
+		}
+	}
+	return v, nil
+}
+
+// scroll moves the view horizontally but not further than the right
+// border of the table
+func (v *Viewer) scroll(delta int) {
+	v.left += delta
+	v.clampLeft()
+}
+
+func (v *Viewer) clampLeft() {
+	if v.dirty || v.lines == nil {
+		v.render()
+	}
+	if limit := term.VisibleWidth(v.lines[0]) - v.width; v.left > limit {
+		v.left = limit
+	}
+	if v.left < 0 {
+		v.left = 0
+	}
+}
+
+// render draws the whole table once so the column sizes do not change
+// while scrolling. It is only called if the rows, the sorting or the
+// filter changed. The selected row is highlighted by View.
+func (v *Viewer) render() {
+	v.lines = strings.Split(strings.TrimRight(v.view.String(), "\n"), "\n")
+	v.header = 2
+	v.footer = 0
+	if v.view.BorderStyle.Size > 0 {
+		v.header++
+		v.footer++
+	}
+	if v.view.Description != "" {
+		v.header++
+	}
+	v.dirty = false
+}
+
+func (v *Viewer) statusLine() string {
+	switch v.mode {
+	case viewerSearch:
+		return "/" + v.input
+	case viewerFilter:
+		return "filter: " + v.input
+	}
+	parts := []string{fmt.Sprintf("%d/%d", v.cursor+1, len(v.view.Rows))}
+	if v.sortCol >= 0 && v.sortCol < len(v.view.TableHeaders) {
+		dir := ARROW_DOWN
+		if v.reverse {
+			dir = ARROW_UP
+		}
+		parts = append(parts, "sort: "+v.view.TableHeaders[v.sortCol].Text+" "+dir)
+	}
+	if v.filter != "" {
+		parts = append(parts, "filter: "+v.filter)
+	}
+	if v.search != "" {
+		parts = append(parts, "search: "+v.search)
+	}
+	if v.status != "" {
+		parts = append(parts, v.status)
+	}
+	parts = append(parts, "q quit, / search, f filter, 1-9 sort")
+	return strings.Join(parts, " | ")
+}
+
+func (v *Viewer) View() string {
+	v.clampLeft()
+	header := v.lines
+	rows := []string{}
+	footer := []string{}
+	if len(v.lines) >= v.header+v.footer {
+		header = v.lines[:v.header]
+		rows = append(rows, v.lines[v.header:len(v.lines)-v.footer]...)
+		footer = v.lines[len(v.lines)-v.footer:]
+	}
+	if v.cursor < len(rows) {
+		rows[v.cursor] = term.SetBackground(rows[v.cursor], term.BACKGROUND_HIGHLIGHTED)
+	}
+	if v.height > 0 {
+		size := v.pageSize()
+		if v.cursor < v.top {
+			v.top = v.cursor
+		}
+		if v.cursor >= v.top+size {
+			v.top = v.cursor - size + 1
+		}
+		if v.top > len(rows) {
+			v.top = len(rows)
+		}
+		end := v.top + size
+		if end > len(rows) {
+			end = len(rows)
+		}
+		rows = rows[v.top:end]
+	}
+	lines := make([]string, 0, len(header)+len(rows)+len(footer)+1)
+	lines = append(lines, header...)
+	lines = append(lines, rows...)
+	lines = append(lines, footer...)
+	if v.left > 0 || v.width > 0 {
+		end := v.left + v.width
+		if v.width <= 0 {
+			end = v.left + term.VisibleWidth(v.lines[0])
+		}
+		for i, l := range lines {
+			lines[i] = term.Cut(l, v.left, end)
+		}
+	}
+	status := v.statusLine()
+	if v.width > 0 {
+		status = term.Truncate(status, v.width)
+	}
+	lines = append(lines, status)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,149 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amecky/table/term"
+)
+
+func viewerTable() *Table {
+	tbl := New().Headers("Name", "Id", "Value")
+	for i, n := range []string{"delta", "alpha", "charlie", "bravo"} {
+		tbl.CreateRow().AddDefaultText(n).AddInt(10-i, 0).AddInt(i*10, 0)
+	}
+	tbl.HideColumn("Id")
+	return tbl
+}
+
+func runViewer(t *testing.T, v *Viewer, keys string) *term.VirtualScreen {
+	vs := term.NewVirtualScreen(80, 20)
+	if _, err := term.Run(v, term.WithInput(strings.NewReader(keys)), term.WithOutput(vs), term.WithSize(80, 20)); err != nil {
+		t.Fatal(err)
+	}
+	return vs
+}
+
+func selectedName(v *Viewer) string {
+	if r := v.Selected(); r != nil {
+		return r.Cells[0].Text
+	}
+	return ""
+}
+
+func TestViewerMoveAndSort(t *testing.T) {
+	v := NewViewer(viewerTable())
+	vs := runViewer(t, v, "jj1")
+	if got := names(v.Table()); got != "alphabravocharliedelta" {
+		t.Errorf("expected the rows sorted by name but got %s", got)
+	}
+	if got := selectedName(v); got != "charlie" {
+		t.Errorf("expected charlie to stay selected but got %s", got)
+	}
+	if !strings.Contains(vs.String(), "sort: Name") {
+		t.Errorf("expected the sort column in the status line\n%s", vs.String())
+	}
+	if strings.Contains(vs.String(), "Id") {
+		t.Errorf("the hidden column must not be shown\n%s", vs.String())
+	}
+	runViewer(t, v, "2")
+	if got := names(v.Table()); got != "bravocharliealphadelta" {
+		t.Errorf("expected the rows sorted by value but got %s", got)
+	}
+	runViewer(t, v, "1")
+	if got := names(v.Table()); got != "alphabravocharliedelta" {
+		t.Errorf("expected the rows sorted by name but got %s", got)
+	}
+	runViewer(t, v, "1")
+	if got := names(v.Table()); got != "deltacharliebravoalpha" {
+		t.Errorf("expected the rows sorted by name in reverse order but got %s", got)
+	}
+}
+
+func TestViewerSearchAndFilter(t *testing.T) {
+	v := NewViewer(viewerTable())
+	vs := runViewer(t, v, "/brav\r")
+	if got := selectedName(v); got != "bravo" {
+		t.Errorf("expected bravo to be selected but got %s", got)
+	}
+	if !strings.Contains(vs.String(), "search: brav") {
+		t.Errorf("expected the search in the status line\n%s", vs.String())
+	}
+	vs = runViewer(t, v, "fValue >= 20\r")
+	if got := names(v.Table()); got != "charliebravo" {
+		t.Errorf("expected the filtered rows but got %s", got)
+	}
+	lines := vs.Lines()
+	if len(lines) != 7 || !strings.Contains(lines[len(lines)-1], "filter: Value >= 20") {
+		t.Errorf("unexpected screen\n%s", vs.String())
+	}
+	runViewer(t, v, "fUnknown > 1\r")
+	if v.filter != "Value >= 20" || !strings.Contains(v.status, "invalid filter") {
+		t.Errorf("expected an invalid filter to be rejected but got %q", v.filter)
+	}
+}
+
+func TestViewerHighlightsWithoutRendering(t *testing.T) {
+	v := NewViewer(viewerTable())
+	v.Update(term.WindowSizeMsg{Width: 20, Height: 10})
+	first := v.View()
+	lines := v.lines
+	v.Update(term.KeyMsg{Key: term.KeyDown})
+	second := v.View()
+	if &v.lines[0] != &lines[0] {
+		t.Error("moving the cursor must not render the table again")
+	}
+	if first == second {
+		t.Error("expected the highlighted row to change")
+	}
+	highlighted := term.SetBackground("", term.BACKGROUND_HIGHLIGHTED)
+	highlighted = highlighted[:strings.Index(highlighted, "m")+1]
+	rows := strings.Split(second, "\n")[v.header : v.header+v.Table().Len()]
+	for i, l := range rows {
+		if strings.HasPrefix(l, highlighted) != (i == 1) {
+			t.Errorf("unexpected highlight of row %d", i)
+		}
+	}
+}
+
+func TestViewerScrollStopsAtTheBorder(t *testing.T) {
+	v := NewViewer(viewerTable())
+	v.Update(term.WindowSizeMsg{Width: 10, Height: 10})
+	for i := 0; i < 20; i++ {
+		v.Update(term.KeyMsg{Key: term.KeyRight})
+	}
+	width := term.VisibleWidth(v.lines[0])
+	if v.left != width-10 {
+		t.Errorf("expected left at %d but got %d", width-10, v.left)
+	}
+	v.Update(term.KeyMsg{Key: term.KeyLeft})
+	if v.left != width-14 {
+		t.Errorf("expected left at %d but got %d", width-14, v.left)
+	}
+}
+
+func TestViewerRefreshKeepsState(t *testing.T) {
+	tbl := New().Headers("Symbol", "Price")
+	for i, s := range []string{"AAA", "BBB", "CCC"} {
+		tbl.CreateKeyedRow(s).AddDefaultText(s).AddFloat(float64(i), 0)
+	}
+	v := NewViewer(tbl)
+	runViewer(t, v, "2k")
+	if got := selectedName(v); got != "BBB" {
+		t.Fatalf("expected BBB to be selected but got %s", got)
+	}
+	tbl.UpsertRow("BBB", func(r *Row) {
+		r.AddDefaultText("BBB").AddFloat(10, 0)
+	})
+	tbl.CreateKeyedRow("DDD").AddDefaultText("DDD").AddFloat(5, 0)
+	v.Refresh()
+	if got := names(v.Table()); got != "BBBDDDCCCAAA" {
+		t.Errorf("expected the changed rows sorted by price but got %s", got)
+	}
+	if got := selectedName(v); got != "BBB" {
+		t.Errorf("expected BBB to stay selected but got %s", got)
+	}
+	if !strings.Contains(v.View(), "10.00") {
+		t.Errorf("expected the new price in\n%s", v.View())
+	}
+}
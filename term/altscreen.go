@@ -0,0 +1,104 @@
+package term
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	EnterAltScreen = CSI + "?1049h"
+	ExitAltScreen  = CSI + "?1049l"
+	ClearScreen    = CSI + "2J"
+	ClearToEnd     = CSI + "K"
+)
+
+// AltScreen shows full frames on the alternate screen of the terminal.
+// Every frame is compared to the previous one and only the changed lines
+// are written using absolute cursor positions.
+type AltScreen struct {
+	out     io.Writer
+	width   int
+	height  int
+	lines   []string
+	started bool
+	resized bool
+}
+
+// NewAltScreen creates an alternate screen writing to w. If w is a
+// terminal its size is used to clip the frames.
+func NewAltScreen(w io.Writer) *AltScreen {
+	s := &AltScreen{out: w}
+	if f, ok := w.(*os.File); ok {
+		if w, h, err := Size(f.Fd()); err == nil {
+			s.SetSize(w, h)
+		}
+	}
+	return s
+}
+
+// SetSize sets the size of the terminal. A size of 0 means unlimited.
+// Changing the size redraws the whole frame on the next update.
+func (s *AltScreen) SetSize(width, height int) {
+	if width != s.width || height != s.height {
+		s.resized = s.started
+		s.width = width
+		s.height = height
+	}
+}
+
+func moveTo(x, y int) string {
+	return fmt.Sprintf("%s%d;%dH", CSI, y+1, x+1)
+}
+
+// Update shows the frame. The alternate screen is entered on the first call.
+func (s *AltScreen) Update(content string) error {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	if s.width > 0 {
+		for i, line := range lines {
+			lines[i] = Truncate(line, s.width)
+		}
+	}
+	if s.height > 0 && len(lines) > s.height {
+		lines = lines[:s.height]
+	}
+	var sb strings.Builder
+	prev := s.lines
+	if !s.started {
+		sb.WriteString(EnterAltScreen + HideCursor + ClearScreen)
+		s.started = true
+		prev = nil
+	}
+	if s.resized {
+		sb.WriteString(ClearScreen)
+		prev = nil
+		s.resized = false
+	}
+	for i, line := range lines {
+		if i < len(prev) && prev[i] == line {
+			continue
+		}
+		sb.WriteString(moveTo(0, i) + line + ClearToEnd)
+	}
+	for i := len(lines); i < len(prev); i++ {
+		sb.WriteString(moveTo(0, i) + ClearLine)
+	}
+	s.lines = lines
+	if sb.Len() == 0 {
+		return nil
+	}
+	_, err := io.WriteString(s.out, sb.String())
+	return err
+}
+
+// Close leaves the alternate screen and restores the previous content of the terminal
+func (s *AltScreen) Close() error {
+	if !s.started {
+		return nil
+	}
+	s.started = false
+	s.lines = nil
+	_, err := io.WriteString(s.out, ShowCursor+ExitAltScreen)
+	return err
+}
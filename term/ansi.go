@@ -0,0 +1,129 @@
+package term
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// sequenceLength returns the length of the escape sequence at the start of s
+// or 0 if s does not start with a CSI sequence
+func sequenceLength(s string) int {
+	if len(s) < 2 || s[0] != ESC || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// StripANSI removes all escape sequences
+func StripANSI(s string) string {
+	if !strings.ContainsRune(s, ESC) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if n := sequenceLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+	return sb.String()
+}
+
+// VisibleWidth returns the number of columns used by the text ignoring
+// escape sequences. Wide characters count as two columns.
+func VisibleWidth(s string) int {
+	return StringWidth(StripANSI(s))
+}
+
+// Truncate cuts the text after width columns. Escape sequences
+// are kept and a reset is appended if a styled text was cut.
+func Truncate(s string, width int) string {
+	if VisibleWidth(s) <= width {
+		return s
+	}
+	var sb strings.Builder
+	visible := 0
+	for i := 0; i < len(s); {
+		if n := sequenceLength(s[i:]); n > 0 {
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := RuneWidth(r)
+		if visible+w > width {
+			break
+		}
+		sb.WriteString(s[i : i+size])
+		visible += w
+		i += size
+	}
+	if strings.ContainsRune(s, ESC) {
+		sb.WriteString(CSI + ResetSeq + "m")
+	}
+	return sb.String()
+}
+
+// Cut returns the visible characters from column start up to end. A wide
+// character which does not fit completely is left out. All escape
+// sequences are kept so the styles of the cut text are preserved.
+func Cut(s string, start, end int) string {
+	if start <= 0 {
+		return Truncate(s, end)
+	}
+	var sb strings.Builder
+	visible := 0
+	styled := false
+	for i := 0; i < len(s); {
+		if n := sequenceLength(s[i:]); n > 0 {
+			sb.WriteString(s[i : i+n])
+			styled = true
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w := RuneWidth(r)
+		if visible+w > end {
+			break
+		}
+		if visible >= start {
+			sb.WriteString(s[i : i+size])
+		}
+		visible += w
+		i += size
+	}
+	if styled {
+		sb.WriteString(CSI + ResetSeq + "m")
+	}
+	return sb.String()
+}
+
+// SetBackground changes the background of the whole text to the color. The
+// background is set again after every escape sequence so the other colors
+// and attributes of the text are kept.
+func SetBackground(s, color string) string {
+	bg := NewStyle("", color, false).sequence()
+	var sb strings.Builder
+	sb.WriteString(bg)
+	for i := 0; i < len(s); {
+		if n := sequenceLength(s[i:]); n > 0 {
+			sb.WriteString(s[i : i+n])
+			if s[i+n-1] == 'm' {
+				sb.WriteString(bg)
+			}
+			i += n
+			continue
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+	sb.WriteString(CSI + ResetSeq + "m")
+	return sb.String()
+}
@@ -1,9 +1,320 @@
 package term
 
+import (
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"time"
+)
+
+// Msg is any message passed to Model.Update like KeyMsg, WindowSizeMsg,
+// TickMsg or the result of a custom command
+type Msg interface{}
+
+// WindowSizeMsg is sent at the start and whenever the terminal is resized
+type WindowSizeMsg struct {
+	Width  int
+	Height int
+}
+
+// TickMsg is sent by Tick when no message function is given
+type TickMsg struct {
+	Time time.Time
+}
+
+// Cmd returns a message which is passed to Update. Commands run in their
+// own goroutine so they can block. A nil Cmd does nothing.
+type Cmd func() Msg
+
+type quitMsg struct{}
+
+type batchMsg []Cmd
+
+type sequenceMsg []Cmd
+
+// Quit stops the program
+func Quit() Msg {
+	return quitMsg{}
+}
+
+// Tick waits for d and returns the message created by fn. If fn is nil a
+// TickMsg is returned. Return the command again from Update to keep ticking.
+func Tick(d time.Duration, fn func(t time.Time) Msg) Cmd {
+	return func() Msg {
+		t := <-time.After(d)
+		if fn == nil {
+			return TickMsg{Time: t}
+		}
+		return fn(t)
+	}
+}
+
+func compact(cmds []Cmd) []Cmd {
+	ret := make([]Cmd, 0, len(cmds))
+	for _, c := range cmds {
+		if c != nil {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Batch runs the commands concurrently
+func Batch(cmds ...Cmd) Cmd {
+	cmds = compact(cmds)
+	switch len(cmds) {
+	case 0:
+		return nil
+	case 1:
+		return cmds[0]
+	}
+	return func() Msg {
+		return batchMsg(cmds)
+	}
+}
+
+// Sequence runs the commands one after another. The message of each
+// command is sent before the next one is started.
+func Sequence(cmds ...Cmd) Cmd {
+	cmds = compact(cmds)
+	if len(cmds) == 0 {
+		return nil
+	}
+	return func() Msg {
+		return sequenceMsg(cmds)
+	}
+}
+
+// Model is the state of a program. Init returns the first command, Update
+// handles a message and returns the new model and an optional command.
+// View renders the model.
 type Model interface {
-	View(m Model) string
+	Init() Cmd
+	Update(msg Msg) (Model, Cmd)
+	View() string
 }
 
-func Run(m Model) {
+type renderer interface {
+	SetSize(width, height int)
+	Update(content string) error
+	Close() error
+}
+
+// Program runs the event loop of a model
+type Program struct {
+	model  Model
+	in     io.Reader
+	out    io.Writer
+	width  int
+	height int
+	alt    bool
+	msgs   chan Msg
+	done   chan struct{}
+	ran    int32
+}
+
+type ProgramOption func(p *Program)
+
+// WithInput reads the keys from r instead of stdin. The program stops at the end of the input.
+func WithInput(r io.Reader) ProgramOption {
+	return func(p *Program) {
+		p.in = r
+	}
+}
+
+// WithOutput writes to w instead of stdout
+func WithOutput(w io.Writer) ProgramOption {
+	return func(p *Program) {
+		p.out = w
+	}
+}
+
+// WithSize sets the size if the output is not a terminal
+func WithSize(width, height int) ProgramOption {
+	return func(p *Program) {
+		p.width = width
+		p.height = height
+	}
+}
+
+// WithAltScreen shows the program on the alternate screen like a full
+// screen application. The previous content of the terminal is restored at the end.
+func WithAltScreen() ProgramOption {
+	return func(p *Program) {
+		p.alt = true
+	}
+}
+
+func NewProgram(m Model, opts ...ProgramOption) *Program {
+	p := &Program{
+		model: m,
+		in:    os.Stdin,
+		out:   os.Stdout,
+		msgs:  make(chan Msg, 64),
+		done:  make(chan struct{}),
+	}
+	for _, o := range opts {
+		o(p)
+	}
+	return p
+}
+
+// Send passes a message to the model. It can be called from other
+// goroutines and does nothing after the program has ended.
+func (p *Program) Send(msg Msg) {
+	select {
+	case p.msgs <- msg:
+	case <-p.done:
+	}
+}
+
+// exec runs the command and sends its message. Batches are started in
+// their own goroutines and sequences are run in order.
+func (p *Program) exec(cmd Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch m := cmd().(type) {
+	case nil:
+	case batchMsg:
+		for _, c := range m {
+			go p.exec(c)
+		}
+	case sequenceMsg:
+		for _, c := range m {
+			p.exec(c)
+		}
+	default:
+		p.Send(m)
+	}
+}
+
+func (p *Program) size() (int, int) {
+	if f, ok := p.out.(*os.File); ok {
+		if w, h, err := Size(f.Fd()); err == nil {
+			return w, h
+		}
+	}
+	return p.width, p.height
+}
+
+// readKeys sends the keys read from the input and Quit at the end of the input.
+// A terminal in raw mode returns from Read without data after a timeout,
+// which is reported as io.EOF, so the reader stops once the program is done.
+func (p *Program) readKeys(raw bool) {
+	buf := make([]byte, 256)
+	var pending []byte
+	for {
+		n, err := p.in.Read(buf)
+		if n > 0 {
+			var keys []KeyMsg
+			keys, pending = ParseKeys(append(pending, buf[:n]...))
+			for _, k := range keys {
+				p.Send(k)
+			}
+		}
+		if raw && n == 0 && err == io.EOF {
+			err = nil
+		}
+		if err != nil {
+			p.Send(quitMsg{})
+			return
+		}
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+	}
+}
+
+// Run starts the event loop and returns the final model. If the input is
+// a terminal it is switched into raw mode until the program ends and the
+// keys are no longer read after Run returned. Any other input is read
+// until it ends, even after Run returned. A program can only run once.
+func (p *Program) Run() (Model, error) {
+	if !atomic.CompareAndSwapInt32(&p.ran, 0, 1) {
+		return p.model, errors.New("the program has already been run")
+	}
+	raw := false
+	if f, ok := p.in.(*os.File); ok {
+		if state, err := makeRaw(f.Fd()); err == nil {
+			raw = true
+			defer restore(f.Fd(), state)
+		}
+	}
+	var screen renderer
+	if p.alt {
+		screen = NewAltScreen(p.out)
+	} else {
+		screen = NewLive(p.out)
+	}
+	defer screen.Close()
+	stopped := make(chan struct{})
+	defer func() {
+		close(p.done)
+		if raw {
+			// the terminal is restored once the reader has stopped
+			<-stopped
+		}
+	}()
+	go func() {
+		p.readKeys(raw)
+		close(stopped)
+	}()
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+	defer signal.Stop(resize)
+
+	model := p.model
+	go p.exec(model.Init())
+	queue := make([]Msg, 0)
+	w, h := p.size()
+	if w > 0 || h > 0 {
+		screen.SetSize(w, h)
+		queue = append(queue, WindowSizeMsg{Width: w, Height: h})
+	}
+	for {
+		for len(queue) > 0 {
+			msg := queue[0]
+			queue = queue[1:]
+			if _, ok := msg.(quitMsg); ok {
+				// the last state stays on the screen
+				return model, screen.Update(model.View())
+			}
+			var cmd Cmd
+			model, cmd = model.Update(msg)
+			if cmd != nil {
+				go p.exec(cmd)
+			}
+		}
+		if err := screen.Update(model.View()); err != nil {
+			return model, err
+		}
+		select {
+		case msg := <-p.msgs:
+			queue = append(queue, msg)
+		case <-resize:
+			w, h := p.size()
+			screen.SetSize(w, h)
+			queue = append(queue, WindowSizeMsg{Width: w, Height: h})
+		}
+		// handle all waiting messages before the next redraw
+	drain:
+		for {
+			select {
+			case msg := <-p.msgs:
+				queue = append(queue, msg)
+			default:
+				break drain
+			}
+		}
+	}
+}
 
+// Run runs the model until it returns Quit or the input ends
+func Run(m Model, opts ...ProgramOption) (Model, error) {
+	return NewProgram(m, opts...).Run()
 }
@@ -0,0 +1,66 @@
+package term
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+// timeoutReader behaves like a terminal in raw mode without any input
+type timeoutReader struct{}
+
+func (timeoutReader) Read(p []byte) (int, error) {
+	time.Sleep(time.Millisecond)
+	return 0, io.EOF
+}
+
+func TestReadKeysStopsWhenDone(t *testing.T) {
+	p := NewProgram(nil, WithInput(timeoutReader{}))
+	stopped := make(chan struct{})
+	go func() {
+		p.readKeys(true)
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("expected the reader to wait for input")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(p.done)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected the reader to stop")
+	}
+	if len(p.msgs) != 0 {
+		t.Errorf("expected no messages but got %d", len(p.msgs))
+	}
+}
+
+func TestReadKeysQuitsAtEndOfInput(t *testing.T) {
+	p := NewProgram(nil, WithInput(timeoutReader{}))
+	p.readKeys(false)
+	if msg := <-p.msgs; msg != (quitMsg{}) {
+		t.Errorf("expected quit but got %v", msg)
+	}
+}
+
+type quitModel struct{}
+
+func (m quitModel) Init() Cmd                   { return Quit }
+func (m quitModel) Update(msg Msg) (Model, Cmd) { return m, nil }
+func (m quitModel) View() string                { return "bye" }
+
+func TestRunTwice(t *testing.T) {
+	vs := NewVirtualScreen(20, 5)
+	p := NewProgram(quitModel{}, WithInput(timeoutReader{}), WithOutput(vs))
+	if _, err := p.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if got := vs.Lines(); len(got) != 1 || got[0] != "bye" {
+		t.Errorf("unexpected screen %q", got)
+	}
+	if _, err := p.Run(); err == nil {
+		t.Error("expected an error for the second run")
+	}
+}
@@ -0,0 +1,384 @@
+package term
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CanvasCell is a single character on the canvas. Char is 0 for an empty
+// cell. The cell right of a wide character is marked as Wide and is not drawn.
+type CanvasCell struct {
+	Char  rune
+	Style Style
+	Wide  bool
+}
+
+// Canvas is a double buffered matrix of styled characters. All drawing
+// goes to the back buffer and is clipped at the borders. Flush compares
+// it to the front buffer and only writes the cells which changed. The
+// canvas is drawn at the top left corner of the screen so it is usually
+// used on the alternate screen.
+type Canvas struct {
+	Width  int
+	Height int
+	out    io.Writer
+	back   [][]CanvasCell
+	front  [][]CanvasCell
+}
+
+func newCells(width, height int) [][]CanvasCell {
+	ret := make([][]CanvasCell, height)
+	for i := range ret {
+		ret[i] = make([]CanvasCell, width)
+	}
+	return ret
+}
+
+func NewCanvas(width, height int) *Canvas {
+	return &Canvas{
+		Width:  width,
+		Height: height,
+		out:    os.Stdout,
+		back:   newCells(width, height),
+	}
+}
+
+// Output writes the canvas to w instead of stdout
+func (c *Canvas) Output(w io.Writer) *Canvas {
+	c.out = w
+	return c
+}
+
+// Resize changes the size of the canvas. The content is kept as far as it
+// fits and the next flush redraws everything.
+func (c *Canvas) Resize(width, height int) {
+	back := newCells(width, height)
+	for y := 0; y < height && y < c.Height; y++ {
+		copy(back[y], c.back[y])
+		if width < c.Width && width > 0 && back[y][width-1].Char != 0 && RuneWidth(back[y][width-1].Char) == 2 {
+			back[y][width-1] = CanvasCell{}
+		}
+	}
+	c.Width = width
+	c.Height = height
+	c.back = back
+	c.front = nil
+}
+
+func (c *Canvas) inside(x, y int) bool {
+	return x >= 0 && x < c.Width && y >= 0 && y < c.Height
+}
+
+// Cell returns the cell at the position or an empty cell if it is outside
+func (c *Canvas) Cell(x, y int) CanvasCell {
+	if !c.inside(x, y) {
+		return CanvasCell{}
+	}
+	return c.back[y][x]
+}
+
+// clear removes the cell and the other half of a wide character
+func (c *Canvas) clear(x, y int) {
+	line := c.back[y]
+	if line[x].Wide && x > 0 {
+		line[x-1] = CanvasCell{Char: ' ', Style: line[x-1].Style}
+	} else if x+1 < c.Width && line[x+1].Wide {
+		line[x+1] = CanvasCell{Char: ' ', Style: line[x].Style}
+	}
+	line[x] = CanvasCell{}
+}
+
+// Set puts the character at the position and returns the number of
+// columns used. A wide character which does not fit is not drawn.
+func (c *Canvas) Set(x, y int, r rune, style Style) int {
+	w := RuneWidth(r)
+	if w == 0 {
+		return 0
+	}
+	if !c.inside(x, y) || (w == 2 && x+1 >= c.Width) {
+		return w
+	}
+	c.clear(x, y)
+	c.back[y][x] = CanvasCell{Char: r, Style: style}
+	if w == 2 {
+		c.clear(x+1, y)
+		c.back[y][x+1] = CanvasCell{Style: style, Wide: true}
+	}
+	return w
+}
+
+// Write draws the text with the style. Every line of the text starts at x.
+// It returns the width of the widest line.
+func (c *Canvas) Write(x, y int, txt string, style Style) int {
+	ret := 0
+	for i, l := range strings.Split(txt, "\n") {
+		xp := x
+		for _, r := range l {
+			xp += c.Set(xp, y+i, r, style)
+		}
+		if xp-x > ret {
+			ret = xp - x
+		}
+	}
+	return ret
+}
+
+// WriteANSI draws a text containing escape sequences like the output of
+// a table or a heatmap. The colors and attributes of the sequences are
+// converted into styles. It returns the width of the widest line.
+func (c *Canvas) WriteANSI(x, y int, txt string) int {
+	ret := 0
+	style := Style{}
+	for i, l := range strings.Split(txt, "\n") {
+		xp := x
+		for j := 0; j < len(l); {
+			if n := sequenceLength(l[j:]); n > 0 {
+				if l[j+n-1] == 'm' {
+					style = style.apply(l[j+2 : j+n-1])
+				}
+				j += n
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(l[j:])
+			xp += c.Set(xp, y+i, r, style)
+			j += size
+		}
+		if xp-x > ret {
+			ret = xp - x
+		}
+	}
+	return ret
+}
+
+// Draw writes the string representation of s at the position. Tables,
+// heatmaps and grids can be drawn this way. It returns the width and the
+// height of the drawn text.
+func (c *Canvas) Draw(x, y int, s fmt.Stringer) (int, int) {
+	txt := strings.TrimRight(s.String(), "\n")
+	return c.WriteANSI(x, y, txt), strings.Count(txt, "\n") + 1
+}
+
+// Clear removes all characters
+func (c *Canvas) Clear() {
+	c.ClearBox(0, 0, c.Width, c.Height)
+}
+
+// ClearBox removes all characters inside the box
+func (c *Canvas) ClearBox(x, y, width, height int) {
+	for j := y; j < y+height; j++ {
+		for i := x; i < x+width; i++ {
+			if c.inside(i, j) {
+				c.clear(i, j)
+			}
+		}
+	}
+}
+
+// FillBox fills the box with spaces using the style
+func (c *Canvas) FillBox(x, y, width, height int, style Style) {
+	for j := y; j < y+height; j++ {
+		for i := x; i < x+width; i++ {
+			c.Set(i, j, ' ', style)
+		}
+	}
+}
+
+// HLine draws a horizontal line
+func (c *Canvas) HLine(x, y, width int, style Style) {
+	c.Write(x, y, strings.Repeat(DefaultBorder.V_LINE, width), style)
+}
+
+// VLine draws a vertical line
+func (c *Canvas) VLine(x, y, height int, style Style) {
+	for j := y; j < y+height; j++ {
+		c.Write(x, j, DefaultBorder.H_LINE, style)
+	}
+}
+
+// Box draws a frame using the default border. Width and height include the frame.
+func (c *Canvas) Box(x, y, width, height int, style Style) {
+	c.Frame(x, y, width, height, DefaultBorder, style)
+}
+
+// Frame draws a frame using the characters of the border
+func (c *Canvas) Frame(x, y, width, height int, border Border, style Style) {
+	if width < 2 || height < 2 || border.Size == 0 {
+		return
+	}
+	line := strings.Repeat(border.V_LINE, width-2)
+	c.Write(x, y, border.TL_CORNER+line+border.TR_CORNER, style)
+	c.Write(x, y+height-1, border.BL_CORNER+line+border.BR_CORNER, style)
+	for j := y + 1; j < y+height-1; j++ {
+		c.Write(x, j, border.H_LINE, style)
+		c.Write(x+width-1, j, border.H_LINE, style)
+	}
+}
+
+// BoxWithHeader draws a frame with the header centered in the top line
+func (c *Canvas) BoxWithHeader(x, y, width, height int, header string, style Style) {
+	c.Box(x, y, width, height, style)
+	txt := " " + header + " "
+	c.Write(x+(width-StringWidth(txt))/2, y, txt, style)
+}
+
+func (c *Canvas) writeCell(sb *strings.Builder, cell CanvasCell, current *Style) {
+	if cell.Style != *current {
+		sb.WriteString(CSI + ResetSeq + "m")
+		sb.WriteString(cell.Style.sequence())
+		*current = cell.Style
+	}
+	if cell.Char == 0 {
+		sb.WriteRune(' ')
+	} else {
+		sb.WriteRune(cell.Char)
+	}
+}
+
+// String returns the content of the canvas as lines of styled text
+func (c *Canvas) String() string {
+	var sb strings.Builder
+	for y, line := range c.back {
+		if y > 0 {
+			sb.WriteRune('\n')
+		}
+		current := Style{}
+		for _, cell := range line {
+			if !cell.Wide {
+				c.writeCell(&sb, cell, &current)
+			}
+		}
+		if current != (Style{}) {
+			sb.WriteString(CSI + ResetSeq + "m")
+		}
+	}
+	return sb.String()
+}
+
+// Flush writes all cells which changed since the last flush. The first
+// flush and the first one after a resize clear the screen and draw everything.
+func (c *Canvas) Flush() error {
+	var sb strings.Builder
+	if c.front == nil {
+		sb.WriteString(ClearScreen)
+		c.front = newCells(c.Width, c.Height)
+		for y := range c.front {
+			for x := range c.front[y] {
+				c.front[y][x].Char = -1
+			}
+		}
+	}
+	current := Style{}
+	cx, cy := -1, -1
+	for y, line := range c.back {
+		for x, cell := range line {
+			if cell.Wide || cell == c.front[y][x] {
+				continue
+			}
+			if x != cx || y != cy {
+				sb.WriteString(moveTo(x, y))
+			}
+			c.writeCell(&sb, cell, &current)
+			cx, cy = x+1, y
+			if cell.Char != 0 && RuneWidth(cell.Char) == 2 {
+				cx++
+			}
+		}
+		copy(c.front[y], line)
+	}
+	if sb.Len() == 0 {
+		return nil
+	}
+	if current != (Style{}) {
+		sb.WriteString(CSI + ResetSeq + "m")
+	}
+	_, err := io.WriteString(c.out, sb.String())
+	return err
+}
+
+// sequence returns the escape sequence selecting the style
+func (s Style) sequence() string {
+	params := make([]string, 0, 4)
+	if s.flags&4 != 0 {
+		params = append(params, "1")
+	}
+	if s.flags&8 != 0 {
+		params = append(params, "9")
+	}
+	if s.flags&1 != 0 {
+		params = append(params, fmt.Sprintf("38;2;%d;%d;%d", s.foreground.r, s.foreground.g, s.foreground.b))
+	}
+	if s.flags&2 != 0 {
+		params = append(params, fmt.Sprintf("48;2;%d;%d;%d", s.background.r, s.background.g, s.background.b))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return CSI + strings.Join(params, ";") + "m"
+}
+
+var basicColors = []string{BLACK, RED, GREEN, YELLOW, BLUE, PURPLE, CYAN, WHITE}
+
+var brightColors = []string{BRIGHT_BLACK, BRIGHT_RED, BRIGHT_GREEN, BRIGHT_YELLOW, BRIGHT_BLUE, BRIGHT_PURPLE, BRIGHT_CYAN, BRIGHT_WHITE}
+
+// apply changes the style according to the parameters of a SGR sequence
+func (s Style) apply(params string) Style {
+	args := strings.Split(params, ";")
+	num := func(i int) int {
+		if i >= len(args) {
+			return 0
+		}
+		v, err := strconv.Atoi(args[i])
+		if err != nil {
+			return 0
+		}
+		return v
+	}
+	rgb := func(i int) Color {
+		return Color{r: byte(num(i)), g: byte(num(i + 1)), b: byte(num(i + 2))}
+	}
+	for i := 0; i < len(args); i++ {
+		switch v := num(i); {
+		case v == 0:
+			s = Style{}
+		case v == 1:
+			s.flags |= 4
+		case v == 9:
+			s.flags |= 8
+		case v == 22:
+			s.flags &^= 4
+		case v == 29:
+			s.flags &^= 8
+		case v >= 30 && v <= 37:
+			s = s.Foreground(basicColors[v-30])
+		case v >= 90 && v <= 97:
+			s = s.Foreground(brightColors[v-90])
+		case v >= 40 && v <= 47:
+			s = s.Background(basicColors[v-40])
+		case v >= 100 && v <= 107:
+			s = s.Background(brightColors[v-100])
+		case v == 39:
+			s.foreground = Color{}
+			s.flags &^= 1
+		case v == 49:
+			s.background = Color{}
+			s.flags &^= 2
+		case (v == 38 || v == 48) && num(i+1) == 2:
+			if v == 38 {
+				s.foreground = rgb(i + 2)
+				s.flags |= 1
+			} else {
+				s.background = rgb(i + 2)
+				s.flags |= 2
+			}
+			i += 4
+		case v == 38 || v == 48:
+			// 256 color mode is not supported
+			i += 2
+		}
+	}
+	return s
+}
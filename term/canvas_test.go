@@ -0,0 +1,89 @@
+package term
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCanvasClipsWideRunes(t *testing.T) {
+	c := NewCanvas(4, 2)
+	if w := c.Write(0, 0, "a世界", Style{}); w != 5 {
+		t.Errorf("expected a width of 5 but got %d", w)
+	}
+	c.Write(-1, 1, "世xy", Style{})
+	expected := "a世 \n xy "
+	if got := c.String(); got != expected {
+		t.Errorf("expected %q but got %q", expected, got)
+	}
+	if cell := c.Cell(2, 0); !cell.Wide {
+		t.Error("expected the second half of the wide rune to be marked")
+	}
+	// overwriting one half of a wide rune removes the other half
+	c.Set(2, 0, 'b', Style{})
+	if got := strings.Split(c.String(), "\n")[0]; got != "a b " {
+		t.Errorf("expected %q but got %q", "a b ", got)
+	}
+	c.Resize(2, 1)
+	c.Write(0, 0, "世", Style{})
+	c.Resize(1, 1)
+	if got := c.String(); got != " " {
+		t.Errorf("expected the cut wide rune to be removed but got %q", got)
+	}
+}
+
+func TestCanvasFlushWritesChanges(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewCanvas(4, 2).Output(&buf)
+	c.Write(0, 0, "ab", Style{})
+	c.Write(0, 1, "cd", Style{})
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.HasPrefix(out, ClearScreen) || !strings.Contains(out, "ab") {
+		t.Errorf("expected the first flush to draw everything but got %q", out)
+	}
+	buf.Reset()
+	c.Flush()
+	if buf.Len() != 0 {
+		t.Errorf("expected nothing to be written but got %q", buf.String())
+	}
+	c.Set(1, 1, 'X', Style{})
+	c.Flush()
+	if out := buf.String(); out != moveTo(1, 1)+"X" {
+		t.Errorf("expected only the changed cell but got %q", out)
+	}
+	buf.Reset()
+	c.Resize(4, 2)
+	c.Flush()
+	if out := buf.String(); !strings.HasPrefix(out, ClearScreen) {
+		t.Errorf("expected a full redraw after a resize but got %q", out)
+	}
+	vs := NewVirtualScreen(4, 2)
+	c.Output(vs).Resize(4, 2)
+	c.Flush()
+	if got := strings.Join(vs.Lines(), "|"); got != "ab|cX" {
+		t.Errorf("unexpected screen %q", got)
+	}
+}
+
+func TestCanvasWriteANSI(t *testing.T) {
+	c := NewCanvas(6, 1)
+	red := NewStyle(RED, "", false)
+	w, h := c.Draw(1, 0, stringer(red.Convert("ab")+"c\n"))
+	if w != 3 || h != 1 {
+		t.Errorf("expected 3x1 but got %dx%d", w, h)
+	}
+	if cell := c.Cell(1, 0); cell.Char != 'a' || cell.Style == (Style{}) {
+		t.Errorf("expected a styled cell but got %+v", cell)
+	}
+	if cell := c.Cell(3, 0); cell.Char != 'c' || cell.Style != (Style{}) {
+		t.Errorf("expected the style to be reset but got %+v", cell)
+	}
+}
+
+type stringer string
+
+func (s stringer) String() string {
+	return string(s)
+}
@@ -0,0 +1,160 @@
+package term
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Profile describes the color capabilities of a terminal
+type Profile int
+
+const (
+	// Ascii supports no colors at all
+	Ascii Profile = iota
+	// ANSI supports the 16 basic colors
+	ANSI
+	// ANSI256 supports the 256 color palette
+	ANSI256
+	// TrueColor supports 24 bit colors
+	TrueColor
+)
+
+// DetectProfile guesses the color profile from the environment
+func DetectProfile() Profile {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return Ascii
+	}
+	ct := strings.ToLower(os.Getenv("COLORTERM"))
+	if ct == "truecolor" || ct == "24bit" {
+		return TrueColor
+	}
+	t := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case t == "" || t == "dumb":
+		return Ascii
+	case strings.Contains(t, "truecolor") || strings.Contains(t, "direct"):
+		return TrueColor
+	case strings.Contains(t, "256color"):
+		return ANSI256
+	}
+	return ANSI
+}
+
+func RGB(r, g, b byte) Color {
+	return Color{r: r, g: g, b: b}
+}
+
+func (c Color) RGB() (byte, byte, byte) {
+	return c.r, c.g, c.b
+}
+
+func (c Color) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+}
+
+func clampByte(v float64) byte {
+	v = math.Round(v)
+	if v < 0.0 {
+		return 0
+	}
+	if v > 255.0 {
+		return 255
+	}
+	return byte(v)
+}
+
+func clampUnit(t float64) float64 {
+	if t < 0.0 {
+		return 0.0
+	}
+	if t > 1.0 {
+		return 1.0
+	}
+	return t
+}
+
+// BlendRGB interpolates linear between the two colors. t is clamped to [0, 1]
+func BlendRGB(a, b Color, t float64) Color {
+	t = clampUnit(t)
+	return Color{
+		r: clampByte(float64(a.r) + (float64(b.r)-float64(a.r))*t),
+		g: clampByte(float64(a.g) + (float64(b.g)-float64(a.g))*t),
+		b: clampByte(float64(a.b) + (float64(b.b)-float64(a.b))*t),
+	}
+}
+
+// BlendLab interpolates between the two colors in the CIE L*a*b* color space
+// which gives perceptually more uniform gradients
+func BlendLab(a, b Color, t float64) Color {
+	t = clampUnit(t)
+	l1, a1, b1 := a.lab()
+	l2, a2, b2 := b.lab()
+	return fromLab(l1+(l2-l1)*t, a1+(a2-a1)*t, b1+(b2-b1)*t)
+}
+
+// D65 reference white
+const (
+	whiteX = 0.95047
+	whiteY = 1.0
+	whiteZ = 1.08883
+)
+
+func toLinear(c byte) float64 {
+	v := float64(c) / 255.0
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func fromLinear(v float64) byte {
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1.0/2.4) - 0.055
+	}
+	return clampByte(v * 255.0)
+}
+
+func labF(t float64) float64 {
+	if t > 216.0/24389.0 {
+		return math.Cbrt(t)
+	}
+	return t*24389.0/27.0/116.0 + 16.0/116.0
+}
+
+func labInvF(t float64) float64 {
+	if t*t*t > 216.0/24389.0 {
+		return t * t * t
+	}
+	return (116.0*t - 16.0) * 27.0 / 24389.0
+}
+
+func (c Color) lab() (float64, float64, float64) {
+	r := toLinear(c.r)
+	g := toLinear(c.g)
+	b := toLinear(c.b)
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / whiteX
+	y := (0.2126729*r + 0.7151522*g + 0.0721750*b) / whiteY
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / whiteZ
+	fx := labF(x)
+	fy := labF(y)
+	fz := labF(z)
+	return 116.0*fy - 16.0, 500.0 * (fx - fy), 200.0 * (fy - fz)
+}
+
+func fromLab(l, a, b float64) Color {
+	fy := (l + 16.0) / 116.0
+	fx := fy + a/500.0
+	fz := fy - b/200.0
+	x := labInvF(fx) * whiteX
+	y := labInvF(fy) * whiteY
+	z := labInvF(fz) * whiteZ
+	return Color{
+		r: fromLinear(3.2404542*x - 1.5371385*y - 0.4985314*z),
+		g: fromLinear(-0.9692660*x + 1.8760108*y + 0.0415560*z),
+		b: fromLinear(0.0556434*x - 0.2040259*y + 1.0572252*z),
+	}
+}
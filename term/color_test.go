@@ -0,0 +1,71 @@
+package term
+
+import (
+	"os"
+	"testing"
+)
+
+func near(c Color, r, g, b byte) bool {
+	d := func(x, y byte) bool { return int(x)-int(y) <= 1 && int(y)-int(x) <= 1 }
+	return d(c.r, r) && d(c.g, g) && d(c.b, b)
+}
+
+func TestBlend(t *testing.T) {
+	black, white := Hex("#000000"), Hex("#ffffff")
+	red, blue := Hex("#ff0000"), Hex("#0000ff")
+	tests := []struct {
+		name    string
+		fn      func(a, b Color, t float64) Color
+		from    Color
+		to      Color
+		t       float64
+		r, g, b byte
+	}{
+		{"rgb start", BlendRGB, black, white, 0, 0, 0, 0},
+		{"rgb end", BlendRGB, black, white, 1, 255, 255, 255},
+		{"rgb mid", BlendRGB, black, white, 0.5, 128, 128, 128},
+		{"rgb clamped", BlendRGB, black, white, 2, 255, 255, 255},
+		{"rgb red blue", BlendRGB, red, blue, 0.5, 128, 0, 128},
+		{"lab start", BlendLab, red, blue, 0, 255, 0, 0},
+		{"lab end", BlendLab, red, blue, 1, 0, 0, 255},
+		// L* 50 is a darker gray than the RGB midpoint
+		{"lab mid", BlendLab, black, white, 0.5, 119, 119, 119},
+		{"lab clamped", BlendLab, black, white, -1, 0, 0, 0},
+	}
+	for _, tc := range tests {
+		if got := tc.fn(tc.from, tc.to, tc.t); !near(got, tc.r, tc.g, tc.b) {
+			t.Errorf("%s: expected %d,%d,%d but got %s", tc.name, tc.r, tc.g, tc.b, got.Hex())
+		}
+	}
+}
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		noColor   bool
+		colorTerm string
+		term      string
+		expected  Profile
+	}{
+		{true, "truecolor", "xterm-256color", Ascii},
+		{false, "truecolor", "xterm", TrueColor},
+		{false, "24bit", "", TrueColor},
+		{false, "", "", Ascii},
+		{false, "", "dumb", Ascii},
+		{false, "", "xterm-direct", TrueColor},
+		{false, "", "xterm-256color", ANSI256},
+		{false, "", "xterm", ANSI},
+	}
+	for _, tc := range tests {
+		t.Setenv("COLORTERM", tc.colorTerm)
+		t.Setenv("TERM", tc.term)
+		if tc.noColor {
+			t.Setenv("NO_COLOR", "1")
+		} else {
+			t.Setenv("NO_COLOR", "")
+			os.Unsetenv("NO_COLOR")
+		}
+		if got := DetectProfile(); got != tc.expected {
+			t.Errorf("%+v: expected %d but got %d", tc, tc.expected, got)
+		}
+	}
+}
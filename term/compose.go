@@ -0,0 +1,181 @@
+package term
+
+import (
+	"fmt"
+	"strings"
+)
+
+type stringerModel struct {
+	s fmt.Stringer
+}
+
+// Static wraps anything with a String method like a table, a heatmap or
+// a grid as a model. It ignores all messages and renders the current
+// content of s on every view.
+func Static(s fmt.Stringer) Model {
+	return stringerModel{s: s}
+}
+
+func (m stringerModel) Init() Cmd {
+	return nil
+}
+
+func (m stringerModel) Update(msg Msg) (Model, Cmd) {
+	return m, nil
+}
+
+func (m stringerModel) View() string {
+	return m.s.String()
+}
+
+type funcModel struct {
+	fn func() string
+}
+
+// ViewFunc creates a model which renders the result of fn
+func ViewFunc(fn func() string) Model {
+	return funcModel{fn: fn}
+}
+
+func (m funcModel) Init() Cmd {
+	return nil
+}
+
+func (m funcModel) Update(msg Msg) (Model, Cmd) {
+	return m, nil
+}
+
+func (m funcModel) View() string {
+	return m.fn()
+}
+
+// Stack places child models below each other or side by side. Keys are
+// passed to the focused child only and tab moves the focus to the next
+// child. All other messages are passed to every child. The width of a
+// horizontal stack is split evenly between the children.
+type Stack struct {
+	Children   []Model
+	Horizontal bool
+	// Gap is the number of empty lines or columns between the children
+	Gap   int
+	Focus int
+}
+
+// VStack places the children below each other
+func VStack(children ...Model) *Stack {
+	return &Stack{Children: children}
+}
+
+// HStack places the children side by side
+func HStack(children ...Model) *Stack {
+	return &Stack{Children: children, Horizontal: true, Gap: 1}
+}
+
+func (s *Stack) Init() Cmd {
+	cmds := make([]Cmd, len(s.Children))
+	for i, c := range s.Children {
+		cmds[i] = c.Init()
+	}
+	return Batch(cmds...)
+}
+
+// Focused returns the child receiving the keys
+func (s *Stack) Focused() Model {
+	if s.Focus >= 0 && s.Focus < len(s.Children) {
+		return s.Children[s.Focus]
+	}
+	return nil
+}
+
+// childSize splits the width of a horizontal or the height of a vertical
+// stack evenly between the children. The last one gets the remainder.
+func (s *Stack) childSize(m WindowSizeMsg, idx int) WindowSizeMsg {
+	n := len(s.Children)
+	if n == 0 {
+		return m
+	}
+	split := func(total int) int {
+		size := (total - s.Gap*(n-1)) / n
+		if idx == n-1 {
+			size = total - (size+s.Gap)*(n-1)
+		}
+		return size
+	}
+	if s.Horizontal {
+		return WindowSizeMsg{Width: split(m.Width), Height: m.Height}
+	}
+	return WindowSizeMsg{Width: m.Width, Height: split(m.Height)}
+}
+
+func (s *Stack) Update(msg Msg) (Model, Cmd) {
+	if k, ok := msg.(KeyMsg); ok {
+		if k.Key == KeyTab && len(s.Children) > 1 {
+			s.Focus = (s.Focus + 1) % len(s.Children)
+			return s, nil
+		}
+		if c := s.Focused(); c != nil {
+			var cmd Cmd
+			s.Children[s.Focus], cmd = c.Update(msg)
+			return s, cmd
+		}
+		return s, nil
+	}
+	cmds := make([]Cmd, len(s.Children))
+	for i, c := range s.Children {
+		m := msg
+		if size, ok := msg.(WindowSizeMsg); ok {
+			m = s.childSize(size, i)
+		}
+		s.Children[i], cmds[i] = c.Update(m)
+	}
+	return s, Batch(cmds...)
+}
+
+func (s *Stack) View() string {
+	views := make([]string, len(s.Children))
+	for i, c := range s.Children {
+		views[i] = strings.TrimRight(c.View(), "\n")
+	}
+	if !s.Horizontal {
+		return strings.Join(views, strings.Repeat("\n", s.Gap+1))
+	}
+	return JoinHorizontal(s.Gap, views...)
+}
+
+// JoinHorizontal places multi-line texts side by side. Every text is
+// padded to its widest line so the columns stay aligned.
+func JoinHorizontal(gap int, texts ...string) string {
+	blocks := make([][]string, len(texts))
+	widths := make([]int, len(texts))
+	height := 0
+	for i, t := range texts {
+		blocks[i] = strings.Split(t, "\n")
+		for _, l := range blocks[i] {
+			if w := VisibleWidth(l); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		if len(blocks[i]) > height {
+			height = len(blocks[i])
+		}
+	}
+	lines := make([]string, height)
+	for y := range lines {
+		var sb strings.Builder
+		for i, b := range blocks {
+			if i > 0 {
+				sb.WriteString(strings.Repeat(" ", gap))
+			}
+			l := ""
+			if y < len(b) {
+				l = b[y]
+			}
+			sb.WriteString(l)
+			if i < len(blocks)-1 {
+				sb.WriteString(strings.Repeat(" ", widths[i]-VisibleWidth(l)))
+			}
+		}
+		lines[y] = sb.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,46 @@
+package term
+
+import "testing"
+
+type sizeModel struct {
+	size WindowSizeMsg
+}
+
+func (m *sizeModel) Init() Cmd {
+	return nil
+}
+
+func (m *sizeModel) Update(msg Msg) (Model, Cmd) {
+	if s, ok := msg.(WindowSizeMsg); ok {
+		m.size = s
+	}
+	return m, nil
+}
+
+func (m *sizeModel) View() string {
+	return ""
+}
+
+func TestStackSplitsSize(t *testing.T) {
+	tests := []struct {
+		horizontal bool
+		expected   []WindowSizeMsg
+	}{
+		{true, []WindowSizeMsg{{Width: 26, Height: 10}, {Width: 26, Height: 10}, {Width: 26, Height: 10}}},
+		{false, []WindowSizeMsg{{Width: 80, Height: 2}, {Width: 80, Height: 2}, {Width: 80, Height: 4}}},
+	}
+	for _, tc := range tests {
+		children := []Model{&sizeModel{}, &sizeModel{}, &sizeModel{}}
+		s := VStack(children...)
+		if tc.horizontal {
+			s = HStack(children...)
+		}
+		s.Gap = 1
+		s.Update(WindowSizeMsg{Width: 80, Height: 10})
+		for i, c := range children {
+			if got := c.(*sizeModel).size; got != tc.expected[i] {
+				t.Errorf("horizontal %v: expected %v for child %d but got %v", tc.horizontal, tc.expected[i], i, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package term
+
+import (
+	"fmt"
+	"strings"
+)
+
+// horizontal alignment of the text in a grid cell
+const (
+	AlignLeft = iota
+	AlignRight
+	AlignCenter
+)
+
+// vertical alignment of the text in a grid cell
+const (
+	AlignTop = iota
+	AlignMiddle
+	AlignBottom
+)
+
+// Renderer is implemented by content which adapts to the available
+// width like a Grid. A width of 0 means the natural width.
+type Renderer interface {
+	Render(width int) string
+}
+
+// Grid places cells next to each other in rows. The width of a cell is
+// either fixed, a percentage or a flex share of the width of the grid or
+// the width of its content.
+type Grid struct {
+	Rows []GridRow
+	// Width is used for percentage and flex cells. If it is 0 these
+	// cells use the width of their content like in Render(0).
+	Width int
+	// Gap is the number of columns between the cells
+	Gap int
+	// RowGap is the number of empty lines between the rows
+	RowGap int
+}
+
+type GridRow struct {
+	Cells   []GridCell
+	Padding int
+}
+
+type GridCell struct {
+	Style Style
+	// Width is the fixed width including padding and border. The cell
+	// grows if the content does not fit.
+	Width int
+	// Percent is the share of the width of the grid
+	Percent int
+	// Flex cells share the remaining width by their ratio
+	Flex int
+	Text string
+	// Content is shown instead of the text. A Renderer like a nested
+	// grid gets the inner width of the cell.
+	Content fmt.Stringer
+	Align   int
+	VAlign  int
+	Plain   bool
+	// Border draws a frame around the cell if the size is greater than 0
+	Border      Border
+	BorderStyle Style
+	Title       string
+	TitleAlign  int
+}
+
+func (g Grid) String() string {
+	return g.Render(g.Width)
+}
+
+// Render lays out the grid using the width. If the width is 0 flex and
+// percentage cells use the width of their content.
+func (g Grid) Render(width int) string {
+	sb := strings.Builder{}
+	for i, r := range g.Rows {
+		if i > 0 {
+			sb.WriteString(strings.Repeat("\n", g.RowGap))
+		}
+		for _, l := range r.render(width, g.Gap) {
+			sb.WriteString(l)
+			sb.WriteRune('\n')
+		}
+	}
+	return sb.String()
+}
+
+func (r GridRow) String() string {
+	sb := strings.Builder{}
+	for _, l := range r.render(0, 0) {
+		sb.WriteString(l)
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+// text returns the lines of the cell rendered with the inner width
+func (c GridCell) text(inner int) []string {
+	if c.Content == nil {
+		return strings.Split(c.Text, "\n")
+	}
+	txt := ""
+	if rd, ok := c.Content.(Renderer); ok {
+		txt = rd.Render(inner)
+	} else {
+		txt = c.Content.String()
+	}
+	return strings.Split(strings.TrimRight(txt, "\n"), "\n")
+}
+
+func maxWidth(lines []string) int {
+	ret := 0
+	for _, l := range lines {
+		if w := VisibleWidth(l); w > ret {
+			ret = w
+		}
+	}
+	return ret
+}
+
+// natural returns the width needed to show the content and the title
+func (c GridCell) natural(padding int) int {
+	frame := 2*padding + 2*c.Border.Size
+	w := maxWidth(c.text(0)) + frame
+	if c.Border.Size > 0 && c.Title != "" {
+		if tw := StringWidth(c.Title) + 4; tw > w {
+			w = tw
+		}
+	}
+	return w
+}
+
+// widths calculates the width of every cell. Fixed and content sized
+// cells are measured first and the flex cells share the rest.
+func (r GridRow) widths(width, gap int) []int {
+	ret := make([]int, len(r.Cells))
+	avail := width - gap*(len(r.Cells)-1)
+	used := 0
+	flex := 0
+	for i, c := range r.Cells {
+		switch {
+		case c.Width > 0:
+			ret[i] = c.Width
+			if n := c.natural(r.Padding); n > ret[i] {
+				ret[i] = n
+			}
+		case c.Percent > 0 && width > 0:
+			ret[i] = avail * c.Percent / 100
+		case c.Flex > 0 && width > 0:
+			flex += c.Flex
+			continue
+		default:
+			ret[i] = c.natural(r.Padding)
+		}
+		used += ret[i]
+	}
+	if flex == 0 {
+		return ret
+	}
+	rest := avail - used
+	if rest < 0 {
+		rest = 0
+	}
+	last := -1
+	shared := 0
+	for i, c := range r.Cells {
+		if c.Width == 0 && c.Percent == 0 && c.Flex > 0 {
+			ret[i] = rest * c.Flex / flex
+			shared += ret[i]
+			last = i
+		}
+	}
+	ret[last] += rest - shared
+	return ret
+}
+
+func (r GridRow) render(width, gap int) []string {
+	widths := r.widths(width, gap)
+	blocks := make([][]string, len(r.Cells))
+	height := 0
+	for i, c := range r.Cells {
+		inner := widths[i] - 2*r.Padding - 2*c.Border.Size
+		if inner < 0 {
+			inner = 0
+		}
+		if width == 0 && c.Width == 0 {
+			// use the natural width of nested content
+			inner = 0
+		}
+		blocks[i] = c.text(inner)
+		if h := len(blocks[i]) + 2*c.Border.Size; h > height {
+			height = h
+		}
+	}
+	for i, c := range r.Cells {
+		blocks[i] = c.block(blocks[i], widths[i], height, r.Padding)
+	}
+	ret := make([]string, height)
+	sep := strings.Repeat(" ", gap)
+	for y := range ret {
+		parts := make([]string, len(blocks))
+		for i, b := range blocks {
+			parts[i] = b[y]
+		}
+		ret[y] = strings.Join(parts, sep)
+	}
+	return ret
+}
+
+func (s Style) paint(txt string, plain bool) string {
+	if plain || txt == "" {
+		return txt
+	}
+	return s.Convert(txt)
+}
+
+// align places the text inside the width
+func align(txt string, width, alignment int, style Style, plain bool) string {
+	d := width - VisibleWidth(txt)
+	if d <= 0 {
+		return style.paint(txt, plain)
+	}
+	switch alignment {
+	case AlignRight:
+		return style.paint(strings.Repeat(" ", d), plain) + style.paint(txt, plain)
+	case AlignCenter:
+		return style.paint(strings.Repeat(" ", d/2), plain) + style.paint(txt, plain) + style.paint(strings.Repeat(" ", d-d/2), plain)
+	}
+	return style.paint(txt, plain) + style.paint(strings.Repeat(" ", d), plain)
+}
+
+// borderLine returns the top or bottom line of the frame with the title
+func (c GridCell) borderLine(width int, top bool) string {
+	if top {
+		return frameLine(c.Border.TL_CORNER, c.Border.V_LINE, c.Border.TR_CORNER, width, c.Title, c.TitleAlign, c.BorderStyle, c.BorderStyle)
+	}
+	return frameLine(c.Border.BL_CORNER, c.Border.V_LINE, c.Border.BR_CORNER, width, "", 0, c.BorderStyle, c.BorderStyle)
+}
+
+// frameLine returns a horizontal line of a frame with an optional text
+// embedded in the line. The text is cut if it does not fit.
+func frameLine(left, line, right string, width int, txt string, alignment int, style, textStyle Style) string {
+	inner := width - VisibleWidth(left) - VisibleWidth(right)
+	if inner < 0 {
+		inner = 0
+	}
+	plain := style == Style{}
+	if txt == "" || inner < 4 {
+		return style.paint(left+strings.Repeat(line, inner)+right, plain)
+	}
+	txt = Truncate(" "+txt+" ", inner-2)
+	d := inner - VisibleWidth(txt)
+	before := 1
+	switch alignment {
+	case AlignRight:
+		before = d - 1
+	case AlignCenter:
+		before = d / 2
+	}
+	return style.paint(left+strings.Repeat(line, before), plain) +
+		textStyle.paint(txt, textStyle == Style{}) +
+		style.paint(strings.Repeat(line, d-before)+right, plain)
+}
+
+// block renders the lines of the cell with exactly the width and the height.
+// The frame and the padding are left out if the width is too small for them.
+func (c GridCell) block(lines []string, width, height, padding int) []string {
+	b := c.Border.Size
+	if width < 2*b {
+		b = 0
+	}
+	if width < 2*b+2*padding {
+		padding = (width - 2*b) / 2
+	}
+	inner := width - 2*padding - 2*b
+	if inner < 0 {
+		inner = 0
+	}
+	rows := height - 2*b
+	offset := 0
+	switch c.VAlign {
+	case AlignMiddle:
+		offset = (rows - len(lines)) / 2
+	case AlignBottom:
+		offset = rows - len(lines)
+	}
+	pad := strings.Repeat(" ", padding)
+	side := ""
+	if b > 0 {
+		side = c.BorderStyle.paint(c.Border.H_LINE, c.BorderStyle == Style{})
+	}
+	ret := make([]string, 0, height)
+	if b > 0 {
+		ret = append(ret, c.borderLine(width, true))
+	}
+	for y := 0; y < rows; y++ {
+		idx := y - offset
+		if idx < 0 || idx >= len(lines) {
+			ret = append(ret, side+strings.Repeat(" ", width-2*b)+side)
+			continue
+		}
+		txt := lines[idx]
+		if VisibleWidth(txt) > inner {
+			txt = Truncate(txt, inner)
+		}
+		ret = append(ret, side+pad+align(txt, inner, c.Align, c.Style, c.Plain)+pad+side)
+	}
+	if b > 0 {
+		ret = append(ret, c.borderLine(width, false))
+	}
+	return ret
+}
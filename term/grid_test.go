@@ -0,0 +1,66 @@
+package term
+
+import (
+	"strings"
+	"testing"
+)
+
+func gridLines(txt string) []string {
+	return strings.Split(strings.TrimRight(txt, "\n"), "\n")
+}
+
+func TestGridWidths(t *testing.T) {
+	r := GridRow{Cells: []GridCell{{Width: 5}, {Percent: 50}, {Flex: 1}, {Flex: 2}}}
+	expected := []int{5, 18, 4, 10}
+	got := r.widths(40, 1)
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v but got %v", expected, got)
+		}
+	}
+	// without a width all cells use the width of their content
+	r = GridRow{Cells: []GridCell{{Percent: 50, Text: "abc"}, {Flex: 1, Text: "世界", Border: DefaultBorder}}}
+	if got := r.widths(0, 0); got[0] != 3 || got[1] != 6 {
+		t.Errorf("expected the natural widths but got %v", got)
+	}
+}
+
+func TestGridLinesHaveTheWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		grid Grid
+	}{
+		{"flex", Grid{Width: 30, Gap: 2, Rows: []GridRow{{Cells: []GridCell{{Flex: 1, Text: "a"}, {Flex: 2, Text: "b\nc"}}}}}},
+		{"percent", Grid{Width: 20, Rows: []GridRow{{Cells: []GridCell{{Percent: 30, Text: "a"}, {Percent: 70, Text: "b", Border: DefaultBorder}}}}}},
+		{"tiny flex frame", Grid{Width: 20, Rows: []GridRow{{Cells: []GridCell{{Width: 20, Text: "x"}, {Flex: 1, Text: "y", Border: DefaultBorder}}}}}},
+		{"one column frame", Grid{Width: 21, Rows: []GridRow{{Padding: 1, Cells: []GridCell{{Width: 20, Text: "x"}, {Flex: 1, Text: "y", Border: DefaultBorder, Title: "T"}}}}}},
+		{"wide runes", Grid{Width: 5, Rows: []GridRow{{Cells: []GridCell{{Flex: 1, Text: "世界世"}}}}}},
+		{"wide runes in frame", Grid{Width: 7, Rows: []GridRow{{Cells: []GridCell{{Flex: 1, Text: "世界世", Border: RoundedBorder, Title: "世界"}}}}}},
+	}
+	for _, tc := range tests {
+		for _, l := range gridLines(tc.grid.String()) {
+			if w := VisibleWidth(l); w != tc.grid.Width {
+				t.Errorf("%s: expected a width of %d but got %d for %q", tc.name, tc.grid.Width, w, l)
+			}
+		}
+	}
+}
+
+func TestGridFrame(t *testing.T) {
+	g := Grid{Rows: []GridRow{{Cells: []GridCell{{Text: "世界", Border: DefaultBorder, Plain: true}, {Text: "ab", Width: 4, Align: AlignRight, Plain: true}}}}}
+	expected := []string{
+		"┌────┐  ab",
+		"│世界│    ",
+		"└────┘    ",
+	}
+	if got := gridLines(g.String()); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected\n%s\nbut got\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+	if g.String() != g.Render(0) {
+		t.Error("expected a grid without width to use the natural width")
+	}
+	small := Grid{Width: 5, Rows: []GridRow{{Cells: []GridCell{{Flex: 1, Text: "世界世", Plain: true}}}}}
+	if got := gridLines(small.String())[0]; got != "世界 " {
+		t.Errorf("expected the wide runes to be cut but got %q", got)
+	}
+}
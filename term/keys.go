@@ -0,0 +1,128 @@
+package term
+
+import "unicode/utf8"
+
+type Key int
+
+const (
+	// KeyRune is a printable character stored in KeyMsg.Rune
+	KeyRune Key = iota
+	KeyEnter
+	KeyEsc
+	KeyTab
+	KeyBackspace
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPgUp
+	KeyPgDown
+	KeyInsert
+	KeyDelete
+	// KeyCtrl is a control character. KeyMsg.Rune contains the letter.
+	KeyCtrl
+)
+
+var keyNames = map[Key]string{
+	KeyEnter:     "enter",
+	KeyEsc:       "esc",
+	KeyTab:       "tab",
+	KeyBackspace: "backspace",
+	KeyUp:        "up",
+	KeyDown:      "down",
+	KeyLeft:      "left",
+	KeyRight:     "right",
+	KeyHome:      "home",
+	KeyEnd:       "end",
+	KeyPgUp:      "pgup",
+	KeyPgDown:    "pgdown",
+	KeyInsert:    "insert",
+	KeyDelete:    "delete",
+}
+
+// KeyMsg is sent to the model for every key press
+type KeyMsg struct {
+	Key  Key
+	Rune rune
+}
+
+// String returns names like "a", "enter", "up" or "ctrl+c"
+func (k KeyMsg) String() string {
+	switch k.Key {
+	case KeyRune:
+		return string(k.Rune)
+	case KeyCtrl:
+		return "ctrl+" + string(k.Rune)
+	}
+	return keyNames[k.Key]
+}
+
+var csiKeys = map[string]Key{
+	"A":  KeyUp,
+	"B":  KeyDown,
+	"C":  KeyRight,
+	"D":  KeyLeft,
+	"H":  KeyHome,
+	"F":  KeyEnd,
+	"1~": KeyHome,
+	"2~": KeyInsert,
+	"3~": KeyDelete,
+	"4~": KeyEnd,
+	"5~": KeyPgUp,
+	"6~": KeyPgDown,
+	"7~": KeyHome,
+	"8~": KeyEnd,
+}
+
+// ParseKeys converts the input into key messages. An incomplete sequence or
+// character at the end is returned so it can be completed by the next read.
+// A single ESC at the end is reported as the escape key.
+func ParseKeys(data []byte) ([]KeyMsg, []byte) {
+	ret := make([]KeyMsg, 0)
+	for i := 0; i < len(data); {
+		b := data[i]
+		switch {
+		case b == ESC:
+			if i+1 >= len(data) || (data[i+1] != '[' && data[i+1] != 'O') {
+				ret = append(ret, KeyMsg{Key: KeyEsc})
+				i++
+				continue
+			}
+			end := i + 2
+			for end < len(data) && (data[end] < 0x40 || data[end] > 0x7e) {
+				end++
+			}
+			if end >= len(data) {
+				return ret, data[i:]
+			}
+			// unknown sequences are ignored
+			if k, ok := csiKeys[string(data[i+2:end+1])]; ok {
+				ret = append(ret, KeyMsg{Key: k})
+			}
+			i = end + 1
+			continue
+		case b == '\r' || b == '\n':
+			ret = append(ret, KeyMsg{Key: KeyEnter})
+		case b == '\t':
+			ret = append(ret, KeyMsg{Key: KeyTab})
+		case b == 127 || b == 8:
+			ret = append(ret, KeyMsg{Key: KeyBackspace})
+		case b > 0 && b <= 26:
+			ret = append(ret, KeyMsg{Key: KeyCtrl, Rune: rune('a' + b - 1)})
+		case b < 0x20:
+			// other control characters are ignored
+		default:
+			if !utf8.FullRune(data[i:]) {
+				return ret, data[i:]
+			}
+			r, size := utf8.DecodeRune(data[i:])
+			ret = append(ret, KeyMsg{Key: KeyRune, Rune: r})
+			i += size
+			continue
+		}
+		i++
+	}
+	return ret, nil
+}
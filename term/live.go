@@ -0,0 +1,176 @@
+package term
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	HideCursor = CSI + "?25l"
+	ShowCursor = CSI + "?25h"
+	ClearLine  = CSI + "2K"
+	ClearDown  = CSI + "J"
+)
+
+// Live redraws a block of text in place. Only the lines which changed
+// since the last update are written again.
+type Live struct {
+	out     io.Writer
+	file    *os.File
+	width   int
+	height  int
+	lines   []string
+	started bool
+	resized bool
+}
+
+// NewLive creates a live view writing to w. If w is a terminal its size
+// is used to cut long lines and to limit the number of lines.
+func NewLive(w io.Writer) *Live {
+	l := &Live{out: w}
+	if f, ok := w.(*os.File); ok {
+		l.file = f
+		l.querySize()
+	}
+	return l
+}
+
+func (l *Live) querySize() {
+	if l.file == nil {
+		return
+	}
+	if w, h, err := Size(l.file.Fd()); err == nil {
+		l.SetSize(w, h)
+	}
+}
+
+// SetSize sets the size of the terminal. A size of 0 means unlimited.
+// Changing the size redraws everything on the next update.
+func (l *Live) SetSize(width, height int) {
+	if width != l.width || height != l.height {
+		l.resized = l.started
+		l.width = width
+		l.height = height
+	}
+}
+
+func cursorUp(n int) string {
+	return fmt.Sprintf("%s%dA", CSI, n)
+}
+
+func cursorDown(n int) string {
+	return fmt.Sprintf("%s%dB", CSI, n)
+}
+
+// Update shows the content. Lines are cut at the width of the terminal
+// and only the lines which fit on the screen are shown.
+func (l *Live) Update(content string) error {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	if l.width > 0 {
+		for i, line := range lines {
+			lines[i] = Truncate(line, l.width)
+		}
+	}
+	if l.height > 1 && len(lines) > l.height-1 {
+		lines = lines[:l.height-1]
+	}
+	var sb strings.Builder
+	prev := l.lines
+	// the cursor is at the start of the line after the block
+	cur := len(prev)
+	move := func(y int) {
+		if y > cur {
+			sb.WriteString(cursorDown(y - cur))
+		} else if y < cur {
+			sb.WriteString(cursorUp(cur - y))
+		}
+		cur = y
+	}
+	if !l.started {
+		sb.WriteString(HideCursor)
+		l.started = true
+	}
+	if l.resized {
+		move(0)
+		sb.WriteString("\r" + ClearDown)
+		prev = nil
+		cur = 0
+		l.resized = false
+	}
+	for i, line := range lines {
+		if i < len(prev) && prev[i] == line {
+			continue
+		}
+		move(i)
+		sb.WriteString("\r" + ClearLine + line)
+		if i < len(prev) {
+			sb.WriteString("\r")
+		} else {
+			sb.WriteString("\r\n")
+			cur++
+		}
+	}
+	for i := len(lines); i < len(prev); i++ {
+		move(i)
+		sb.WriteString(ClearLine)
+	}
+	move(len(lines))
+	l.lines = lines
+	_, err := io.WriteString(l.out, sb.String())
+	return err
+}
+
+// Close shows the cursor again. The text stays on the screen.
+func (l *Live) Close() error {
+	if !l.started {
+		return nil
+	}
+	l.started = false
+	l.lines = nil
+	_, err := io.WriteString(l.out, ShowCursor)
+	return err
+}
+
+// Run calls fn every interval and shows the result until the context is
+// done or the program is interrupted. Resizing the terminal redraws the view.
+// With an interval <= 0 the view is only redrawn on resize.
+func (l *Live) Run(ctx context.Context, interval time.Duration, fn func() string) error {
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(resize)
+	defer signal.Stop(stop)
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	if err := l.Update(fn()); err != nil {
+		l.Close()
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return l.Close()
+		case <-stop:
+			return l.Close()
+		case <-resize:
+			l.querySize()
+		case <-tick:
+			l.querySize()
+		}
+		if err := l.Update(fn()); err != nil {
+			l.Close()
+			return err
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package term
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLiveShrinkAndGrow(t *testing.T) {
+	vs := NewVirtualScreen(20, 10)
+	l := NewLive(vs)
+	steps := []string{"a\nb\nc", "a\nB", "a\nB\nc\nd", "x"}
+	for _, content := range steps {
+		if err := l.Update(content); err != nil {
+			t.Fatal(err)
+		}
+		expected := strings.Split(content, "\n")
+		if got := vs.Lines(); strings.Join(got, "|") != strings.Join(expected, "|") {
+			t.Errorf("expected %q but got %q", expected, got)
+		}
+		if x, y := vs.Cursor(); x != 0 || y != len(expected) {
+			t.Errorf("expected the cursor after the block at 0,%d but got %d,%d", len(expected), x, y)
+		}
+	}
+	if vs.CursorVisible {
+		t.Error("expected a hidden cursor while updating")
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !vs.CursorVisible {
+		t.Error("expected a visible cursor after Close")
+	}
+}
+
+func TestLiveWritesOnlyChangedLines(t *testing.T) {
+	vs := NewVirtualScreen(20, 10)
+	l := NewLive(vs)
+	l.Update("first\nsecond\nthird")
+	before := vs.Written
+	l.Update("first\nsecond\nthird")
+	if vs.Written != before {
+		t.Errorf("expected no output for the same content but got %d bytes", vs.Written-before)
+	}
+	l.Update("first\nchanged\nthird")
+	if vs.Written-before > len("changed")+20 {
+		t.Errorf("expected only the changed line to be written but got %d bytes", vs.Written-before)
+	}
+	if got := vs.String(); got != "first\nchanged\nthird" {
+		t.Errorf("unexpected screen %q", got)
+	}
+}
+
+func TestLiveCutsToSize(t *testing.T) {
+	vs := NewVirtualScreen(5, 3)
+	l := NewLive(vs)
+	l.SetSize(5, 3)
+	l.Update("abcdefgh\n2\n3\n4")
+	if got := vs.String(); got != "abcde\n2" {
+		t.Errorf("unexpected screen %q", got)
+	}
+	vs.Width, vs.Height = 10, 5
+	l.SetSize(10, 5)
+	l.Update("abcdefgh\n2\n3")
+	if got := vs.String(); got != "abcdefgh\n2\n3" {
+		t.Errorf("unexpected screen after resize %q", got)
+	}
+}
+
+func TestLiveRunWithoutInterval(t *testing.T) {
+	vs := NewVirtualScreen(20, 10)
+	l := NewLive(vs)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	calls := 0
+	err := l.Run(ctx, 0, func() string {
+		calls++
+		return "static"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single update but got %d", calls)
+	}
+	if got := vs.Lines(); len(got) != 1 || got[0] != "static" {
+		t.Errorf("unexpected screen %q", got)
+	}
+}
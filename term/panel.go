@@ -0,0 +1,177 @@
+package term
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Panel draws a frame around a text or any content with a String method
+// like a table or a heatmap. The title is shown in the top line and the
+// footer in the bottom line of the frame. Escape sequences in the content
+// are ignored when measuring. A Border with size 0 shows the title and the
+// footer as lines above and below the content.
+type Panel struct {
+	Text string
+	// Content is shown instead of the text. A Renderer like a grid gets
+	// the inner width of the panel.
+	Content     fmt.Stringer
+	Title       string
+	TitleAlign  int
+	Footer      string
+	FooterAlign int
+	// Align is the horizontal alignment of the content
+	Align int
+	// Padding is the number of spaces left and right of the content
+	Padding int
+	// VPadding is the number of empty lines above and below the content
+	VPadding int
+	// Width is the fixed width including the frame. If it is 0 the panel
+	// is as wide as the content, the title and the footer.
+	Width int
+	// Height is the fixed height including the frame. If it is 0 all lines are shown.
+	Height      int
+	Border      Border
+	BorderStyle Style
+	// TitleStyle is used for the title and the footer. The border style is used if it is not set.
+	TitleStyle Style
+	// Style is applied to the content if it is set
+	Style Style
+}
+
+// NewPanel creates a panel with the default border showing the text
+func NewPanel(title, text string) Panel {
+	return Panel{
+		Text:    text,
+		Title:   title,
+		Padding: 1,
+		Border:  DefaultBorder,
+	}
+}
+
+// NewContentPanel creates a panel with the default border showing the content
+func NewContentPanel(title string, content fmt.Stringer) Panel {
+	p := NewPanel(title, "")
+	p.Content = content
+	return p
+}
+
+func (p Panel) String() string {
+	return p.Render(p.Width)
+}
+
+func (p Panel) lines(inner int) []string {
+	txt := p.Text
+	if p.Content != nil {
+		if rd, ok := p.Content.(Renderer); ok {
+			txt = rd.Render(inner)
+		} else {
+			txt = p.Content.String()
+		}
+	}
+	return strings.Split(strings.TrimRight(txt, "\n"), "\n")
+}
+
+func (p Panel) textStyle() Style {
+	if p.TitleStyle == (Style{}) {
+		return p.BorderStyle
+	}
+	return p.TitleStyle
+}
+
+// Render draws the panel with the width. If the width is 0 the panel is
+// as wide as its content. A width smaller than the frame reduces the
+// padding and a height without room for the content leaves out the frame
+// and then the footer and the title.
+func (p Panel) Render(width int) string {
+	b := p.Border.Size
+	title, footer := p.Title, p.Footer
+	if p.Height > 0 {
+		if p.Height-2*b < 1 {
+			b = 0
+		}
+		if b == 0 && footer != "" && p.Height-textLines(title, footer) < 1 {
+			footer = ""
+		}
+		if b == 0 && title != "" && p.Height-textLines(title) < 1 {
+			title = ""
+		}
+	}
+	padding := p.Padding
+	if width > 0 && width < 2*b+2*padding {
+		if width < 2*b {
+			width = 2 * b
+		}
+		padding = (width - 2*b) / 2
+	}
+	frame := 2*b + 2*padding
+	inner := 0
+	if width > 0 {
+		inner = width - frame
+	}
+	lines := p.lines(inner)
+	if width <= 0 {
+		inner = maxWidth(lines)
+		for _, t := range []string{title, footer} {
+			if t == "" {
+				continue
+			}
+			w := VisibleWidth(t)
+			if b > 0 {
+				// the text is surrounded by spaces and at least one line on each side
+				w += 4 - 2*padding
+			}
+			if w > inner {
+				inner = w
+			}
+		}
+		width = inner + frame
+	}
+	for i := 0; i < p.VPadding; i++ {
+		lines = append([]string{""}, lines...)
+		lines = append(lines, "")
+	}
+	if p.Height > 0 {
+		rows := p.Height - 2*b
+		if b == 0 {
+			rows -= textLines(title, footer)
+		}
+		for len(lines) < rows {
+			lines = append(lines, "")
+		}
+		lines = lines[:rows]
+	}
+	ret := make([]string, 0, len(lines)+2)
+	if b > 0 {
+		ret = append(ret, frameLine(p.Border.TL_CORNER, p.Border.V_LINE, p.Border.TR_CORNER, width, title, p.TitleAlign, p.BorderStyle, p.textStyle()))
+	} else if title != "" {
+		ret = append(ret, align(Truncate(title, width), width, p.TitleAlign, p.textStyle(), p.textStyle() == Style{}))
+	}
+	side := ""
+	if b > 0 {
+		side = p.BorderStyle.paint(p.Border.H_LINE, p.BorderStyle == Style{})
+	}
+	pad := strings.Repeat(" ", padding)
+	for _, l := range lines {
+		if VisibleWidth(l) > inner {
+			l = Truncate(l, inner)
+		}
+		ret = append(ret, side+pad+align(l, inner, p.Align, p.Style, p.Style == Style{})+pad+side)
+	}
+	if b > 0 {
+		ret = append(ret, frameLine(p.Border.BL_CORNER, p.Border.V_LINE, p.Border.BR_CORNER, width, footer, p.FooterAlign, p.BorderStyle, p.textStyle()))
+	} else if footer != "" {
+		ret = append(ret, align(Truncate(footer, width), width, p.FooterAlign, p.textStyle(), p.textStyle() == Style{}))
+	}
+	return strings.Join(ret, "\n")
+}
+
+// textLines counts the texts which are not empty
+func textLines(texts ...string) int {
+	ret := 0
+	for _, t := range texts {
+		if t != "" {
+			ret++
+		}
+	}
+	return ret
+}
@@ -0,0 +1,86 @@
+package term
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPanelNaturalSize(t *testing.T) {
+	p := NewPanel("Title", "ab\n世界")
+	p.Footer = "2"
+	p.FooterAlign = AlignRight
+	expected := []string{
+		"┌─ Title ─┐",
+		"│ ab      │",
+		"│ 世界    │",
+		"└───── 2 ─┘",
+	}
+	if got := p.String(); got != strings.Join(expected, "\n") {
+		t.Errorf("expected\n%s\nbut got\n%s", strings.Join(expected, "\n"), got)
+	}
+	p.TitleAlign = AlignCenter
+	p.Align = AlignRight
+	p.Border = HiddenBorder
+	p.Border.Size = 0
+	expected = []string{
+		" Title ",
+		"    ab ",
+		"  世界 ",
+		"      2",
+	}
+	if got := p.String(); got != strings.Join(expected, "\n") {
+		t.Errorf("expected\n%s\nbut got\n%s", strings.Join(expected, "\n"), got)
+	}
+}
+
+func TestPanelStyledContent(t *testing.T) {
+	red := NewStyle(RED, "", false)
+	p := NewContentPanel("T", stringer(red.Convert("abc")+"\n世"))
+	lines := strings.Split(p.String(), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines but got %d", len(lines))
+	}
+	for _, l := range lines {
+		if w := VisibleWidth(l); w != 7 {
+			t.Errorf("expected a width of 7 but got %d for %q", w, l)
+		}
+	}
+	if !strings.Contains(lines[1], red.Convert("abc")) {
+		t.Errorf("expected the styled content in %q", lines[1])
+	}
+}
+
+func TestPanelFixedSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		height   int
+		expected []string
+	}{
+		{"cut", 8, 4, []string{"┌─ Tit─┐", "│ ab   │", "│ 世界 │", "└─ f ──┘"}},
+		{"filled", 6, 5, []string{"┌─ T─┐", "│ ab │", "│ 世 │", "│    │", "└─ f─┘"}},
+		{"narrow", 3, 0, []string{"┌─┐", "│a│", "│ │", "└─┘"}},
+		{"tiny", 1, 0, []string{"┌┐", "││", "││", "└┘"}},
+		{"flat", 6, 2, []string{"Title ", " ab   "}},
+		{"one line", 6, 1, []string{" ab   "}},
+	}
+	for _, tc := range tests {
+		p := NewPanel("Title", "ab\n世界")
+		p.Footer = "f"
+		p.Width = tc.width
+		p.Height = tc.height
+		lines := strings.Split(p.String(), "\n")
+		if tc.height > 0 && len(lines) != tc.height {
+			t.Errorf("%s: expected %d lines but got %d", tc.name, tc.height, len(lines))
+		}
+		width := VisibleWidth(lines[0])
+		for _, l := range lines {
+			if w := VisibleWidth(l); w != width || (tc.width >= 2 && w != tc.width) {
+				t.Errorf("%s: unexpected width %d of %q", tc.name, w, l)
+			}
+		}
+		if strings.Join(lines, "\n") != strings.Join(tc.expected, "\n") {
+			t.Errorf("%s: expected\n%s\nbut got\n%s", tc.name, strings.Join(tc.expected, "\n"), strings.Join(lines, "\n"))
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd
+
+package term
+
+import "errors"
+
+type terminalState struct{}
+
+// makeRaw is not supported on this platform
+func makeRaw(fd uintptr) (*terminalState, error) {
+	return nil, errors.New("raw mode is not supported")
+}
+
+func restore(fd uintptr, state *terminalState) error {
+	return nil
+}
@@ -0,0 +1,47 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+// +build linux darwin freebsd netbsd openbsd
+
+package term
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+type terminalState struct {
+	termios syscall.Termios
+}
+
+func ioctlTermios(fd uintptr, req uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// makeRaw disables line buffering, echo and signals of the terminal.
+// The output processing is kept so that "\n" still starts a new line.
+// Reads return without data after 100ms so a reader can be stopped.
+func makeRaw(fd uintptr) (*terminalState, error) {
+	state := &terminalState{}
+	if err := ioctlTermios(fd, ioctlReadTermios, &state.termios); err != nil {
+		return nil, err
+	}
+	raw := state.termios
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 0
+	raw.Cc[syscall.VTIME] = 1
+	if err := ioctlTermios(fd, ioctlWriteTermios, &raw); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// restore resets the terminal to the state before makeRaw
+func restore(fd uintptr, state *terminalState) error {
+	return ioctlTermios(fd, ioctlWriteTermios, &state.termios)
+}
@@ -0,0 +1,218 @@
+package term
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// VirtualScreen is an io.Writer which interprets the cursor movement and
+// erase sequences written by Live and Program. It can be used instead of
+// a real terminal to check the output. Colors are ignored.
+type VirtualScreen struct {
+	Width         int
+	Height        int
+	CursorVisible bool
+	// Written counts the bytes written to the screen
+	Written int
+	lines   [][]rune
+	saved   [][]rune
+	x       int
+	y       int
+	pending []byte
+}
+
+// NewVirtualScreen creates a screen of the given size. A size of 0 means unlimited.
+func NewVirtualScreen(width, height int) *VirtualScreen {
+	return &VirtualScreen{
+		Width:         width,
+		Height:        height,
+		CursorVisible: true,
+	}
+}
+
+func (vs *VirtualScreen) Write(p []byte) (int, error) {
+	vs.Written += len(p)
+	data := append(vs.pending, p...)
+	vs.pending = nil
+	for i := 0; i < len(data); {
+		if data[i] == ESC {
+			n := sequenceLength(string(data[i:]))
+			if n == 0 || data[i+n-1] < 0x40 {
+				if i+1 < len(data) && data[i+1] != '[' {
+					// ignore unsupported escape sequences
+					i += 2
+					continue
+				}
+				vs.pending = append([]byte(nil), data[i:]...)
+				break
+			}
+			vs.sequence(string(data[i+2:i+n-1]), data[i+n-1])
+			i += n
+			continue
+		}
+		if !utf8.FullRune(data[i:]) {
+			vs.pending = append([]byte(nil), data[i:]...)
+			break
+		}
+		r, size := utf8.DecodeRune(data[i:])
+		vs.put(r)
+		i += size
+	}
+	return len(p), nil
+}
+
+func (vs *VirtualScreen) line(y int) []rune {
+	for len(vs.lines) <= y {
+		vs.lines = append(vs.lines, nil)
+	}
+	return vs.lines[y]
+}
+
+func (vs *VirtualScreen) newLine() {
+	vs.y++
+	if vs.Height > 0 && vs.y >= vs.Height {
+		vs.line(vs.y)
+		vs.lines = vs.lines[1:]
+		vs.y = vs.Height - 1
+	}
+}
+
+func (vs *VirtualScreen) put(r rune) {
+	switch r {
+	case '\r':
+		vs.x = 0
+		return
+	case '\n':
+		vs.newLine()
+		return
+	case '\b':
+		if vs.x > 0 {
+			vs.x--
+		}
+		return
+	}
+	w := RuneWidth(r)
+	if w == 0 {
+		return
+	}
+	if vs.Width > 0 && vs.x+w > vs.Width {
+		vs.x = 0
+		vs.newLine()
+	}
+	l := vs.line(vs.y)
+	for len(l) < vs.x+w {
+		l = append(l, ' ')
+	}
+	l[vs.x] = r
+	if w == 2 {
+		// the second column of a wide character is skipped by Lines
+		l[vs.x+1] = 0
+	}
+	vs.lines[vs.y] = l
+	vs.x += w
+}
+
+func (vs *VirtualScreen) sequence(params string, final byte) {
+	private := strings.HasPrefix(params, "?")
+	params = strings.TrimPrefix(params, "?")
+	args := make([]int, 0)
+	for _, p := range strings.Split(params, ";") {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			v = 0
+		}
+		args = append(args, v)
+	}
+	arg := func(idx, def int) int {
+		if idx < len(args) && args[idx] > 0 {
+			return args[idx]
+		}
+		return def
+	}
+	if private {
+		switch {
+		case params == "25":
+			vs.CursorVisible = final == 'h'
+		case params == "1049" && final == 'h':
+			vs.saved = vs.lines
+			vs.lines = nil
+			vs.x, vs.y = 0, 0
+		case params == "1049" && final == 'l':
+			vs.lines = vs.saved
+			vs.saved = nil
+		}
+		return
+	}
+	switch final {
+	case 'A':
+		vs.y -= arg(0, 1)
+		if vs.y < 0 {
+			vs.y = 0
+		}
+	case 'B':
+		vs.y += arg(0, 1)
+		if vs.Height > 0 && vs.y >= vs.Height {
+			vs.y = vs.Height - 1
+		}
+	case 'C':
+		vs.x += arg(0, 1)
+	case 'D':
+		vs.x -= arg(0, 1)
+		if vs.x < 0 {
+			vs.x = 0
+		}
+	case 'G':
+		vs.x = arg(0, 1) - 1
+	case 'H':
+		vs.y = arg(0, 1) - 1
+		vs.x = arg(1, 1) - 1
+	case 'K':
+		l := vs.line(vs.y)
+		switch arg(0, 0) {
+		case 0:
+			if vs.x < len(l) {
+				l = l[:vs.x]
+			}
+		case 1:
+			for i := 0; i < vs.x && i < len(l); i++ {
+				l[i] = ' '
+			}
+		case 2:
+			l = nil
+		}
+		vs.lines[vs.y] = l
+	case 'J':
+		switch arg(0, 0) {
+		case 0:
+			l := vs.line(vs.y)
+			if vs.x < len(l) {
+				vs.lines[vs.y] = l[:vs.x]
+			}
+			vs.lines = vs.lines[:vs.y+1]
+		case 2, 3:
+			vs.lines = nil
+		}
+	}
+}
+
+// Cursor returns the column and line of the cursor
+func (vs *VirtualScreen) Cursor() (int, int) {
+	return vs.x, vs.y
+}
+
+// Lines returns the text of all lines without trailing spaces
+func (vs *VirtualScreen) Lines() []string {
+	ret := make([]string, len(vs.lines))
+	for i, l := range vs.lines {
+		ret[i] = strings.TrimRight(strings.Replace(string(l), "\x00", "", -1), " ")
+	}
+	for len(ret) > 0 && ret[len(ret)-1] == "" {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
+
+func (vs *VirtualScreen) String() string {
+	return strings.Join(vs.Lines(), "\n")
+}
@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd
+
+package term
+
+import (
+	"errors"
+	"os"
+)
+
+// Size is not supported on this platform
+func Size(fd uintptr) (int, int, error) {
+	return 0, 0, errors.New("terminal size is not supported")
+}
+
+// notifyResize is not supported on this platform. Resizes are detected by polling.
+func notifyResize(c chan<- os.Signal) {
+}
@@ -2,8 +2,6 @@ package term
 
 import (
 	"fmt"
-	"strings"
-	"unicode/utf8"
 )
 
 const (
@@ -69,6 +67,14 @@ func NewStyle(f, b string, bld bool) Style {
 	return s
 }
 
+func (s Style) Foreground(f string) Style {
+	if f != "" {
+		s.foreground = Hex(f)
+		s.flags = s.flags | 1
+	}
+	return s
+}
+
 func (s Style) Background(b string) Style {
 	if b != "" {
 		s.background = Hex(b)
@@ -77,11 +83,20 @@ func (s Style) Background(b string) Style {
 	return s
 }
 
+// Strikethrough draws a line through the text
+func (s Style) Strikethrough() Style {
+	s.flags = s.flags | 8
+	return s
+}
+
 func (s Style) Convert(t string) string {
 	b := newBuffer()
 	if s.flags&4 != 0 {
 		b.bold()
 	}
+	if s.flags&8 != 0 {
+		b.strike()
+	}
 	if s.flags&1 != 0 {
 		b.forground(s.foreground)
 	}
@@ -183,6 +198,14 @@ func (b *styleBuffer) bold() *styleBuffer {
 	return b
 }
 
+func (b *styleBuffer) strike() *styleBuffer {
+	if b.index > 2 {
+		b.append(';')
+	}
+	b.append('9')
+	return b
+}
+
 func (b *styleBuffer) forground(c Color) *styleBuffer {
 	if b.index > 2 {
 		b.append(';')
@@ -235,142 +258,3 @@ func (b *styleBuffer) String() string {
 	b.index = 2
 	return ret
 }
-
-type Grid struct {
-	Rows []GridRow
-}
-
-func (g Grid) String() string {
-	sb := strings.Builder{}
-	for _, r := range g.Rows {
-		sb.WriteString(r.String())
-
-	}
-	return sb.String()
-}
-
-type GridRow struct {
-	Cells   []GridCell
-	Padding int
-}
-
-type GridCell struct {
-	Style Style
-	Width int
-	Text  string
-	Align int
-	Plain bool
-}
-
-func isSequenceChar(r rune) bool {
-	if r == ESC {
-		return true
-	}
-	if r == 0 {
-		return true
-	}
-	if r >= 48 && r <= 57 {
-		return true
-	}
-	if r == 59 {
-		return true
-	}
-	return false
-}
-
-func internalLen(txt string) int {
-	return utf8.RuneCountInString(txt)
-}
-
-func internalLength(s string) int {
-	if strings.Contains(s, CSI) {
-		pos := make([]int, 0)
-		for i := 0; i < len(s)-2; i++ {
-			if s[i] == ESC && s[i+1] == '[' && s[i+2] != '0' {
-				pos = append(pos, i)
-			}
-		}
-		l := 0
-		for i, p := range pos {
-			if i < len(pos)-1 {
-				t := s[p+3 : pos[i+1]]
-				start := 0
-				for _, c := range t {
-					if isSequenceChar(c) {
-						start++
-					} else {
-						break
-					}
-				}
-				t = t[start+1:]
-				l += internalLen(t)
-			}
-		}
-		return l
-	}
-	return len(s)
-}
-
-func (r GridRow) String() string {
-
-	lines := make([][]string, 0)
-	for _, c := range r.Cells {
-		entries := strings.Split(c.Text, "\n")
-		if len(entries) > 0 {
-			lines = append(lines, entries)
-		} else {
-			lines = append(lines, []string{c.Text})
-		}
-	}
-	ll := make([]int, 0)
-	cnt := 0
-	for i := 0; i < len(lines); i++ {
-		ll = append(ll, len(lines[i]))
-		if len(lines[i]) > cnt {
-			cnt = len(lines[i])
-
-		}
-	}
-	sb := strings.Builder{}
-	for i := 0; i < cnt; i++ {
-		for j, c := range r.Cells {
-			if ll[j] > i {
-				txt := lines[j][i]
-				if r.Padding > 0 {
-					sb.WriteString(strings.Repeat(" ", r.Padding))
-				}
-				d := c.Width - internalLength(txt) - 2*r.Padding
-				if c.Align == 1 && d > 0 {
-					if c.Plain {
-						sb.WriteString(strings.Repeat(" ", d))
-					} else {
-						sb.WriteString(c.Style.Convert(strings.Repeat(" ", d)))
-					}
-				}
-				if c.Plain {
-					sb.WriteString(txt)
-				} else {
-					sb.WriteString(c.Style.Convert(txt))
-				}
-				if c.Align == 0 && d > 0 {
-					if c.Plain {
-						sb.WriteString(strings.Repeat(" ", d))
-					} else {
-						sb.WriteString(c.Style.Convert(strings.Repeat(" ", d)))
-					}
-				}
-				if r.Padding > 0 {
-					sb.WriteString(strings.Repeat(" ", r.Padding))
-				}
-			} else {
-				if c.Plain {
-					sb.WriteString(strings.Repeat(" ", c.Width))
-				} else {
-					sb.WriteString(strings.Repeat(" ", c.Width))
-				}
-			}
-		}
-		sb.WriteRune('\n')
-	}
-	return sb.String()
-}
@@ -0,0 +1,77 @@
+package term
+
+import "unicode/utf8"
+
+type runeRange struct {
+	from rune
+	to   rune
+}
+
+// combining marks and other characters which do not take any space
+var zeroWidth = []runeRange{
+	{0x0300, 0x036f},
+	{0x0483, 0x0489},
+	{0x0591, 0x05bd},
+	{0x1ab0, 0x1aff},
+	{0x1dc0, 0x1dff},
+	{0x200b, 0x200f},
+	{0x20d0, 0x20ff},
+	{0xfe00, 0xfe0f},
+	{0xfe20, 0xfe2f},
+}
+
+// east asian wide and fullwidth characters and emojis
+var doubleWidth = []runeRange{
+	{0x1100, 0x115f},
+	{0x2e80, 0x303e},
+	{0x3041, 0x33ff},
+	{0x3400, 0x4dbf},
+	{0x4e00, 0x9fff},
+	{0xa000, 0xa4cf},
+	{0xac00, 0xd7a3},
+	{0xf900, 0xfaff},
+	{0xfe30, 0xfe4f},
+	{0xff00, 0xff60},
+	{0xffe0, 0xffe6},
+	{0x1f300, 0x1f64f},
+	{0x1f900, 0x1f9ff},
+	{0x20000, 0x3fffd},
+}
+
+func inRanges(r rune, ranges []runeRange) bool {
+	for _, rr := range ranges {
+		if r < rr.from {
+			return false
+		}
+		if r <= rr.to {
+			return true
+		}
+	}
+	return false
+}
+
+// RuneWidth returns the number of columns used by the rune on the terminal
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7f:
+		return 0
+	case r < 0x300:
+		return 1
+	case inRanges(r, zeroWidth):
+		return 0
+	case inRanges(r, doubleWidth):
+		return 2
+	}
+	return 1
+}
+
+// StringWidth returns the number of columns used by a text without escape sequences
+func StringWidth(s string) int {
+	w := 0
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		w += RuneWidth(r)
+		s = s[size:]
+	}
+	return w
+}